@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- One finding per endpoint ---
+
+// severityLevels orders severity values from weakest to strongest.
+var severityLevels = []pluginv1.Severity{
+	sdk.SeverityInfo,
+	sdk.SeverityLow,
+	sdk.SeverityMedium,
+	sdk.SeverityHigh,
+	sdk.SeverityCritical,
+}
+
+// endpointIssue is one rule hit rolled into an aggregated endpoint finding.
+type endpointIssue struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Confidence string `json:"confidence"`
+	Message    string `json:"message"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+}
+
+// endpointGroup collects the findings for one normalized endpoint.
+type endpointGroup struct {
+	first     *pluginv1.Finding
+	inventory *pluginv1.Finding
+	issues    []*pluginv1.Finding
+}
+
+// aggregateByEndpoint replaces every finding that concerns an endpoint with
+// a single ATTACK-001 finding per normalized endpoint. Its issues metadata
+// is a JSON array of the rolled-up rule hits, and its severity and
+// confidence are those of the most severe issue. Findings without an
+// endpoint are kept as they are.
+func aggregateByEndpoint(out *pluginv1.InvokeToolResponse) {
+	groups := make(map[string]*endpointGroup)
+	var kept []*pluginv1.Finding
+	for _, f := range out.GetFindings() {
+		endpoint := f.GetMetadata()["endpoint"]
+		if endpoint == "" {
+			kept = append(kept, f)
+			continue
+		}
+		key := normalizeEndpoint(endpoint)
+		g, ok := groups[key]
+		if !ok {
+			g = &endpointGroup{first: f}
+			groups[key] = g
+			// Placeholder, replaced by the aggregate below.
+			kept = append(kept, f)
+		}
+		if f.GetRuleId() == "ATTACK-001" && g.inventory == nil {
+			g.inventory = f
+			continue
+		}
+		g.issues = append(g.issues, f)
+	}
+
+	for i, f := range kept {
+		if g, ok := groups[normalizeEndpoint(f.GetMetadata()["endpoint"])]; ok && g.first == f {
+			kept[i] = g.aggregate()
+		}
+	}
+	out.Findings = kept
+}
+
+// aggregate builds the rolled-up finding for the group.
+func (g *endpointGroup) aggregate() *pluginv1.Finding {
+	// The inventory finding carries endpoint-level metadata (drift,
+	// external_path); issue-specific metadata stays in the issues array.
+	location := g.first.GetLocation()
+	endpoint := g.first.GetMetadata()["endpoint"]
+	metadata := map[string]string{"endpoint": endpoint}
+	if g.inventory != nil {
+		location = g.inventory.GetLocation()
+		for k, v := range g.inventory.GetMetadata() {
+			metadata[k] = v
+		}
+	}
+
+	severity, confidence := sdk.SeverityInfo, rulesByID["ATTACK-001"].Confidence
+	issues := make([]endpointIssue, 0, len(g.issues))
+	rules := make([]string, 0, len(g.issues))
+	for _, f := range g.issues {
+		if severityRank(f.GetSeverity()) > severityRank(severity) {
+			severity, confidence = f.GetSeverity(), f.GetConfidence()
+		}
+		issues = append(issues, endpointIssue{
+			Rule:       f.GetRuleId(),
+			Severity:   severityNames[f.GetSeverity()],
+			Confidence: confidenceNames[f.GetConfidence()],
+			Message:    f.GetMessage(),
+			File:       f.GetLocation().GetFilePath(),
+			Line:       int(f.GetLocation().GetStartLine()),
+		})
+		rules = append(rules, f.GetRuleId())
+	}
+	data, _ := json.Marshal(issues)
+	metadata["issues"] = string(data)
+	metadata["issue_count"] = strconv.Itoa(len(issues))
+
+	route := endpoint
+	if method := metadata["method"]; method != "" {
+		route = method + " " + endpoint
+	}
+	message := fmt.Sprintf("HTTP endpoint detected: %s", route)
+	if len(issues) > 0 {
+		message = fmt.Sprintf("HTTP endpoint %s has %d issue(s): %s", route, len(issues), strings.Join(rules, ", "))
+	}
+	return &pluginv1.Finding{
+		RuleId:     "ATTACK-001",
+		Severity:   severity,
+		Confidence: confidence,
+		Message:    message,
+		Location:   location,
+		Metadata:   metadata,
+	}
+}
+
+// severityRank returns the position of s in severityLevels.
+func severityRank(s pluginv1.Severity) int {
+	for i, level := range severityLevels {
+		if level == s {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Hardcoded API base URLs ---
+
+var (
+	// Absolute http(s) URLs inside string literals.
+	reQuotedURL = regexp.MustCompile("[\"'`](https?://[^\"'`\\s]+)")
+
+	// URL paths that look like API endpoints rather than pages or assets.
+	reAPIPath = regexp.MustCompile(`(?i)^/(?:api|v\d+|graphql|rest|rpc)(?:/|$)`)
+
+	// Hostnames naming a production environment.
+	reProductionHost = regexp.MustCompile(`(?i)(?:^|[.-])(?:prod|production|live)(?:[.-]|$)`)
+)
+
+// publicCDNHosts are well-known public CDNs, which are not reported.
+var publicCDNHosts = []string{
+	"cdn.jsdelivr.net", "unpkg.com", "cdnjs.cloudflare.com", "ajax.googleapis.com",
+	"fonts.googleapis.com", "fonts.gstatic.com", "code.jquery.com",
+	"stackpath.bootstrapcdn.com", "maxcdn.bootstrapcdn.com", "cdn.skypack.dev", "esm.sh",
+}
+
+// ignoredURLHosts are placeholder, documentation, and local development
+// hosts that never indicate real infrastructure.
+var ignoredURLHosts = []string{
+	"localhost", "example.com", "example.org", "example.net", "www.w3.org", "schemas.xmlsoap.org",
+}
+
+// internalHostSuffixes mark hosts that only resolve inside a private network.
+var internalHostSuffixes = []string{
+	".internal", ".local", ".localdomain", ".corp", ".lan", ".intranet", ".svc", ".cluster.local",
+}
+
+// classifyURLHost returns "internal", "production", or "external" for a
+// host worth inventorying, "cdn" for public CDNs, or "" for hosts that are
+// ignored. apiPath reports whether the URL path looks like an API.
+func classifyURLHost(host string, apiPath bool) string {
+	host = strings.ToLower(host)
+	if containsString(ignoredURLHosts, host) || strings.HasSuffix(host, ".example.com") {
+		return ""
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsUnspecified() {
+			return ""
+		}
+		if ip.IsPrivate() {
+			return "internal"
+		}
+		return "external"
+	}
+	if containsString(publicCDNHosts, host) {
+		return "cdn"
+	}
+	if !strings.Contains(host, ".") {
+		return "internal"
+	}
+	for _, suffix := range internalHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return "internal"
+		}
+	}
+	if reProductionHost.MatchString(host) {
+		return "production"
+	}
+	if apiPath || strings.HasPrefix(host, "api.") || strings.HasPrefix(host, "api-") {
+		return "external"
+	}
+	return ""
+}
+
+// isCommentLine reports whether line is a whole-line comment.
+func isCommentLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "#", "/*", "*", "<!--"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiURLUse is one occurrence of a hardcoded URL.
+type apiURLUse struct {
+	path string
+	file string
+	line int
+}
+
+// apiURLInventory groups hardcoded API URLs by host across the workspace.
+type apiURLInventory struct {
+	kinds map[string]string
+	uses  map[string][]apiURLUse
+}
+
+func newAPIURLInventory() *apiURLInventory {
+	return &apiURLInventory{kinds: make(map[string]string), uses: make(map[string][]apiURLUse)}
+}
+
+// scanLine records the API URLs in string literals on line. Comment lines
+// are skipped so that documentation links are not inventoried.
+func (inv *apiURLInventory) scanLine(line, file string, lineNum int) {
+	if !strings.Contains(line, "://") || isCommentLine(line) {
+		return
+	}
+	for _, m := range reQuotedURL.FindAllStringSubmatch(line, -1) {
+		u, err := url.Parse(m[1])
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		kind := classifyURLHost(u.Hostname(), reAPIPath.MatchString(u.Path))
+		if kind == "" || kind == "cdn" {
+			continue
+		}
+		host := strings.ToLower(u.Host)
+		inv.kinds[host] = kind
+		inv.uses[host] = append(inv.uses[host], apiURLUse{path: u.Path, file: file, line: lineNum})
+	}
+}
+
+// merge adds the URLs other recorded after inv's.
+func (inv *apiURLInventory) merge(other *apiURLInventory) {
+	for host, kind := range other.kinds {
+		inv.kinds[host] = kind
+	}
+	for host, uses := range other.uses {
+		inv.uses[host] = append(inv.uses[host], uses...)
+	}
+}
+
+// report emits one ATTACK-072 per host at its first use, listing the
+// distinct URL paths and every location in metadata.
+func (inv *apiURLInventory) report(resp *sdk.ResponseBuilder) {
+	hosts := make([]string, 0, len(inv.uses))
+	for host := range inv.uses {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		uses := inv.uses[host]
+		var paths, locations []string
+		for _, u := range uses {
+			if u.path != "" && !containsString(paths, u.path) {
+				paths = append(paths, u.path)
+			}
+			locations = append(locations, fmt.Sprintf("%s:%d", u.file, u.line))
+		}
+		first := uses[0]
+		newFinding(
+			resp,
+			"ATTACK-072",
+			fmt.Sprintf("Hardcoded %s API host %s referenced %d time(s)", inv.kinds[host], host, len(uses)),
+		).
+			At(first.file, first.line, first.line).
+			WithMetadata("host", host).
+			WithMetadata("host_type", inv.kinds[host]).
+			WithMetadata("path", first.path).
+			WithMetadata("url_paths", strings.Join(paths, ",")).
+			WithMetadata("count", strconv.Itoa(len(uses))).
+			WithMetadata("locations", strings.Join(locations, ",")).
+			Done()
+	}
+}
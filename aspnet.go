@@ -0,0 +1,55 @@
+package main
+
+import "strings"
+
+// --- ASP.NET Core controller routes ---
+
+// controllerRouteTracker joins attribute-routed action templates with the
+// [Route] prefix of the enclosing controller class.
+type controllerRouteTracker struct {
+	// pending is a class-level [Route] template awaiting its class.
+	pending string
+	// prefix is the route of the current controller.
+	prefix string
+}
+
+// apply returns the full endpoint for the route extracted from lines[i],
+// or "" when the line is a class-level [Route] rather than an endpoint.
+// [controller] is replaced with the class name minus its Controller
+// suffix; templates starting with / or ~/ ignore the prefix.
+func (t *controllerRouteTracker) apply(lines []string, i int, endpoint string) string {
+	line := lines[i]
+	if m := reCsRouteAttribute.FindStringSubmatch(line); len(m) > 1 && isClassAttribute(lines, i) {
+		t.pending = m[1]
+		return ""
+	}
+	if m := reCsClass.FindStringSubmatch(line); len(m) > 1 {
+		t.prefix = strings.ReplaceAll(t.pending, "[controller]", strings.TrimSuffix(m[1], "Controller"))
+		t.pending = ""
+	}
+	if endpoint == "" {
+		return ""
+	}
+
+	if reCsMinimalAPI.MatchString(line) {
+		return "/" + strings.TrimLeft(endpoint, "/")
+	}
+	if strings.HasPrefix(endpoint, "~/") || (strings.HasPrefix(endpoint, "/") && endpoint != "/") {
+		return "/" + strings.TrimLeft(strings.TrimPrefix(endpoint, "~"), "/")
+	}
+	return joinRoutePath("/"+strings.TrimLeft(t.prefix, "/"), endpoint)
+}
+
+// isClassAttribute reports whether the attribute on lines[i] decorates a
+// class: the next line that is neither blank nor another attribute
+// declares one.
+func isClassAttribute(lines []string, i int) bool {
+	for _, line := range lines[i+1:] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "[") {
+			continue
+		}
+		return reCsClass.MatchString(trimmed)
+	}
+	return false
+}
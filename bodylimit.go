@@ -0,0 +1,105 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- Request body size limits ---
+
+// bodyLimitThreshold is the largest request body limit considered
+// reasonable; configured limits above it are flagged by ATTACK-056.
+const bodyLimitThreshold = 10 << 20
+
+// bodySizeSettings recognizes request body size configuration. Each
+// pattern captures the configured value.
+var bodySizeSettings = []struct {
+	setting string
+	re      *regexp.Regexp
+}{
+	{"body-parser limit", regexp.MustCompile(`(?:bodyParser|express)\.(?:json|urlencoded|raw|text)\s*\(\s*\{[^}]*\blimit\s*:\s*['"]?([^'",}]+)`)},
+	{"bodyLimit", regexp.MustCompile(`\bbodyLimit\s*:\s*([^,}\n]+)`)},
+	{"multer fileSize", regexp.MustCompile(`\bfileSize\s*:\s*([^,}\n]+)`)},
+	{"DATA_UPLOAD_MAX_MEMORY_SIZE", regexp.MustCompile(`\bDATA_UPLOAD_MAX_MEMORY_SIZE\s*=\s*([^#\n]+)`)},
+	{"FILE_UPLOAD_MAX_MEMORY_SIZE", regexp.MustCompile(`\bFILE_UPLOAD_MAX_MEMORY_SIZE\s*=\s*([^#\n]+)`)},
+	{"MAX_CONTENT_LENGTH", regexp.MustCompile(`\bMAX_CONTENT_LENGTH['"]?\]?\s*=\s*([^#\n]+)`)},
+	{"MaxMultipartMemory", regexp.MustCompile(`\.MaxMultipartMemory\s*=\s*([^\n/]+)`)},
+	{"MaxBytesReader", regexp.MustCompile(`MaxBytesReader\s*\([^,]+,[^,]+,\s*([^)]+)\)`)},
+}
+
+var (
+	// Go handlers reading the request body.
+	reGoBodyRead = regexp.MustCompile(`(?:io\.ReadAll|ioutil\.ReadAll|json\.NewDecoder)\s*\(\s*r\.Body|r\.ParseMultipartForm\s*\(`)
+
+	// Size literals such as "50mb", "1.5 GB", or "2048".
+	reSizeLiteral = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)\s*([kmgt]?)b?$`)
+)
+
+// parseByteSize interprets a configured size: unit literals ("50mb"),
+// integer products ("10 * 1024 * 1024"), and shifts ("32 << 20"). It
+// returns -1 for values that disable the limit (None, null, Infinity) and
+// false when the value cannot be interpreted.
+func parseByteSize(s string) (int64, bool) {
+	s = strings.Trim(strings.TrimSpace(s), `'"`)
+	switch strings.ToLower(s) {
+	case "none", "null", "infinity", "-1":
+		return -1, true
+	}
+	s = strings.ReplaceAll(s, "_", "")
+
+	if base, shift, ok := strings.Cut(s, "<<"); ok {
+		b, err1 := strconv.ParseInt(strings.TrimSpace(base), 10, 64)
+		n, err2 := strconv.ParseUint(strings.TrimSpace(shift), 10, 6)
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return b << n, true
+	}
+
+	if strings.Contains(s, "*") {
+		product := int64(1)
+		for _, factor := range strings.Split(s, "*") {
+			n, err := strconv.ParseInt(strings.TrimSpace(factor), 10, 64)
+			if err != nil {
+				return 0, false
+			}
+			product *= n
+		}
+		return product, true
+	}
+
+	m := reSizeLiteral.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	multiplier := map[string]float64{"": 1, "k": 1 << 10, "m": 1 << 20, "g": 1 << 30, "t": 1 << 40}[strings.ToLower(m[2])]
+	return int64(n * multiplier), true
+}
+
+// excessiveBodyLimit returns the setting name and configured value when
+// line sets a request body limit above bodyLimitThreshold or disables it.
+func excessiveBodyLimit(line string) (setting, value string) {
+	for _, bs := range bodySizeSettings {
+		m := bs.re.FindStringSubmatch(line)
+		if len(m) < 2 {
+			continue
+		}
+		raw := strings.TrimSpace(m[1])
+		size, ok := parseByteSize(raw)
+		if !ok {
+			continue
+		}
+		if size < 0 {
+			return bs.setting, "unlimited"
+		}
+		if size > bodyLimitThreshold {
+			return bs.setting, raw
+		}
+	}
+	return "", ""
+}
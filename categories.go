@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Rule category filtering ---
+
+// categoryFilter selects findings by rule category. A nil filter keeps
+// every finding.
+type categoryFilter struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+// parseCategoryFilter reads the include_categories and exclude_categories
+// inputs. It returns nil when neither is set.
+func parseCategoryFilter(include, exclude any) (*categoryFilter, error) {
+	in, err := parseCategorySet(include, "include_categories")
+	if err != nil {
+		return nil, err
+	}
+	ex, err := parseCategorySet(exclude, "exclude_categories")
+	if err != nil {
+		return nil, err
+	}
+	if in == nil && ex == nil {
+		return nil, nil
+	}
+	return &categoryFilter{include: in, exclude: ex}, nil
+}
+
+// parseCategorySet reads a list of category names, rejecting names that
+// are not in ruleCategories.
+func parseCategorySet(v any, input string) (map[string]bool, error) {
+	names, err := parseStringList(v, input)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !containsString(ruleCategories, name) {
+			return nil, fmt.Errorf("%s: unknown category %q", input, name)
+		}
+		set[name] = true
+	}
+	return set, nil
+}
+
+// keep reports whether findings in category pass the filter. Exclusions
+// win over inclusions.
+func (c *categoryFilter) keep(category string) bool {
+	if c.exclude[category] {
+		return false
+	}
+	return c.include == nil || c.include[category]
+}
+
+// apply removes findings whose rule category the filter rejects.
+// Diagnostics are kept unless their category is excluded explicitly, so
+// that partial or failed scans stay visible in focused passes.
+func (c *categoryFilter) apply(out *pluginv1.InvokeToolResponse) {
+	kept := out.Findings[:0]
+	for _, f := range out.GetFindings() {
+		category := rulesByID[f.GetRuleId()].Category
+		if (category == categoryDiagnostics && !c.exclude[category]) || c.keep(category) {
+			kept = append(kept, f)
+		}
+	}
+	out.Findings = kept
+}
+
+// tagCategories records each finding's rule category in its metadata.
+func tagCategories(out *pluginv1.InvokeToolResponse) {
+	for _, f := range out.GetFindings() {
+		r, ok := rulesByID[f.GetRuleId()]
+		if !ok {
+			continue
+		}
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string)
+		}
+		f.Metadata["category"] = r.Category
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Confidence floor ---
+
+// parseMinConfidence reads the min_confidence input ("low", "medium", or
+// "high"). It returns 0 when unset, which keeps every finding.
+func parseMinConfidence(v any) (pluginv1.Confidence, error) {
+	if v == nil {
+		return 0, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("min_confidence must be a string, got %T", v)
+	}
+	for _, level := range confidenceLevels {
+		if strings.EqualFold(s, confidenceNames[level]) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("min_confidence must be low, medium, or high, got %q", s)
+}
+
+// confidenceRank returns the position of c in confidenceLevels.
+func confidenceRank(c pluginv1.Confidence) int {
+	for i, level := range confidenceLevels {
+		if level == c {
+			return i
+		}
+	}
+	return 0
+}
+
+// applyMinConfidence removes findings below floor. Diagnostics are kept so
+// that partial or failed scans stay visible.
+func applyMinConfidence(out *pluginv1.InvokeToolResponse, floor pluginv1.Confidence) {
+	kept := out.Findings[:0]
+	for _, f := range out.GetFindings() {
+		if f.GetRuleId() == "ATTACK-000" || confidenceRank(f.GetConfidence()) >= confidenceRank(floor) {
+			kept = append(kept, f)
+		}
+	}
+	out.Findings = kept
+}
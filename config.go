@@ -0,0 +1,292 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Workspace configuration file ---
+
+// workspaceConfigNames are the config files looked up in the workspace
+// root, in order of preference.
+var workspaceConfigNames = []string{".nox-attack-surface.yaml", ".nox-attack-surface.yml"}
+
+// configInputKinds lists the inputs a workspace config may set and the
+// YAML value kinds each accepts. workspace_root, git_diff, and diff_hunks
+// describe a single run and can only be passed as tool inputs, as can
+// webhook_url: the config comes from the scanned repository, which must
+// not choose where findings are sent.
+var configInputKinds = map[string][]string{
+	"absolute_paths":        {"bool"},
+	"aggregate_by_endpoint": {"bool"},
+	"baseline_path":         {"string"},
+	"coverage_report":       {"bool"},
+	"csv_output":            {"string"},
+	"exclude_categories":    {"list"},
+	"file_scores":           {"bool", "object"},
+	"id_prefix":             {"string"},
+	"include_categories":    {"list"},
+	"include_dirs":          {"list"},
+	"include_hidden":        {"bool"},
+	"inventory_output":      {"string"},
+	"languages":             {"list"},
+	"manifest_output":       {"string"},
+	"min_confidence":        {"string"},
+	"per_file_timeout":      {"string"},
+	"progress_interval":     {"string"},
+	"progress_output":       {"string"},
+	"public_endpoints":      {"list"},
+	"report_suppressed":     {"bool"},
+	"resolve_proxy_paths":   {"bool"},
+	"risk_scores":           {"bool", "object"},
+	"scan_dockerfiles":      {"bool"},
+	"scan_templates":        {"bool"},
+	"scan_terraform":        {"bool"},
+	"scan_timeout":          {"string"},
+	"sensitivity_keywords":  {"bool", "object"},
+	"service_root_depth":    {"number"},
+	"skip_submodules":       {"bool"},
+	"suppressions":          {"string"},
+	"top_n":                 {"number"},
+	"untested_endpoints":    {"bool"},
+}
+
+// loadWorkspaceConfig reads the workspace config file, if any, and returns
+// its path, the valid inputs it sets, and one error per problem found.
+// Invalid entries are dropped so that one typo does not discard the rest
+// of the file. Path inputs are resolved against root when relative. The
+// file comes from the scanned repository, so path inputs that resolve
+// outside root are invalid, as are references to environment variables:
+// a config must neither read or overwrite files elsewhere on the machine
+// running the scan nor read that machine's environment.
+func loadWorkspaceConfig(root string) (string, map[string]any, []error) {
+	for _, name := range workspaceConfigNames {
+		path := filepath.Join(root, name)
+		lines, err := readLines(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return path, nil, []error{err}
+		}
+		values, errs := parseConfigYAML(lines)
+		return path, validateConfig(root, values, &errs), errs
+	}
+	return "", nil, nil
+}
+
+// validateConfig keeps the entries of values that name a known input with
+// a value of an accepted kind, appending an error to errs for every other
+// entry.
+func validateConfig(root string, values map[string]any, errs *[]error) map[string]any {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cfg := make(map[string]any, len(values))
+	for _, k := range keys {
+		v := values[k]
+		kinds, ok := configInputKinds[k]
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("unknown input %q", k))
+			continue
+		}
+		if kind := configValueKind(v); !containsString(kinds, kind) {
+			*errs = append(*errs, fmt.Errorf("%s must be %s, got %s", k, strings.Join(kinds, " or "), kind))
+			continue
+		}
+		if s, ok := v.(string); ok && containsString(pathInputs, k) {
+			if names := envReferences(s); len(names) > 0 {
+				*errs = append(*errs, fmt.Errorf("%s: environment variables are not expanded in the workspace config, got $%s in %q", k, strings.Join(names, ", $"), s))
+				continue
+			}
+			path := s
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(root, path)
+			}
+			path = filepath.Clean(path)
+			if !insideRoot(root, path) {
+				*errs = append(*errs, fmt.Errorf("%s: %q is outside the workspace", k, s))
+				continue
+			}
+			v = path
+		}
+		cfg[k] = v
+	}
+	return cfg
+}
+
+// insideRoot reports whether path is root or lies below it once symlinks
+// are resolved, so that a symlinked directory in the workspace cannot lead
+// a path elsewhere. Paths whose symlinks cannot be resolved are outside.
+func insideRoot(root, path string) bool {
+	root, err := resolveSymlinks(root)
+	if err != nil {
+		return false
+	}
+	path, err = resolveSymlinks(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolveSymlinks resolves the symlinks in the deepest existing ancestor
+// of the clean path, keeping the components below it, which do not exist
+// yet, as they are. A dangling symlink is an error.
+func resolveSymlinks(path string) (string, error) {
+	var rest []string
+	for p := path; ; p = filepath.Dir(p) {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if _, lerr := os.Lstat(p); lerr == nil {
+			return "", err
+		}
+		if filepath.Dir(p) == p {
+			return path, nil
+		}
+		rest = append([]string{filepath.Base(p)}, rest...)
+	}
+}
+
+// mergeConfig returns input with every config entry that input does not
+// set itself. Tool inputs always win.
+func mergeConfig(input, cfg map[string]any) map[string]any {
+	out := make(map[string]any, len(input)+len(cfg))
+	for k, v := range cfg {
+		out[k] = v
+	}
+	for k, v := range input {
+		out[k] = v
+	}
+	return out
+}
+
+// configValueKind names the kind of a parsed config value.
+func configValueKind(v any) string {
+	switch v.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// parseConfigYAML parses the YAML subset used by the workspace config:
+// top-level keys with scalar values, inline [a, b] or block "- a" lists,
+// and one level of nested key: scalar mappings. Values are typed like
+// tool inputs: booleans, float64 numbers, strings, []any, map[string]any.
+func parseConfigYAML(lines []string) (map[string]any, []error) {
+	values := make(map[string]any)
+	var errs []error
+	key := "" // top-level key whose block is open
+	for i, line := range lines {
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+		lineNum := i + 1
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			k, v, ok := strings.Cut(trimmed, ":")
+			if !ok {
+				errs = append(errs, fmt.Errorf("line %d: expected key: value", lineNum))
+				key = ""
+				continue
+			}
+			k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+			if v == "" {
+				key = k
+				continue
+			}
+			key = ""
+			values[k] = parseConfigValue(v)
+			continue
+		}
+
+		if key == "" {
+			errs = append(errs, fmt.Errorf("line %d: unexpected indentation", lineNum))
+			continue
+		}
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok || trimmed == "-" {
+			list, isList := values[key].([]any)
+			if _, set := values[key]; set && !isList {
+				errs = append(errs, fmt.Errorf("line %d: %s mixes list items and keys", lineNum, key))
+				continue
+			}
+			values[key] = append(list, parseConfigScalar(strings.TrimSpace(item)))
+			continue
+		}
+		k, v, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: expected key: value or - item under %s", lineNum, key))
+			continue
+		}
+		obj, isObj := values[key].(map[string]any)
+		if _, set := values[key]; set && !isObj {
+			errs = append(errs, fmt.Errorf("line %d: %s mixes list items and keys", lineNum, key))
+			continue
+		}
+		if obj == nil {
+			obj = make(map[string]any)
+			values[key] = obj
+		}
+		obj[unquote(strings.TrimSpace(k))] = parseConfigScalar(strings.TrimSpace(v))
+	}
+	return values, errs
+}
+
+// parseConfigValue parses an inline value: a [a, b] list or a scalar.
+func parseConfigValue(v string) any {
+	if inner, ok := strings.CutPrefix(v, "["); ok && strings.HasSuffix(inner, "]") {
+		items := []any{}
+		for _, item := range splitConfigList(strings.TrimSuffix(inner, "]")) {
+			items = append(items, parseConfigScalar(item))
+		}
+		return items
+	}
+	return parseConfigScalar(v)
+}
+
+// parseConfigScalar types a scalar: quoted strings stay strings, true and
+// false are booleans, and numeric literals are numbers.
+func parseConfigScalar(v string) any {
+	if unquoted := unquote(v); unquoted != v {
+		return unquoted
+	}
+	switch v {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseFloat(v, 64); err == nil {
+		return n
+	}
+	return v
+}
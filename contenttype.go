@@ -0,0 +1,126 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Reflected responses without a safe Content-Type ---
+
+var (
+	// Go handler signatures; groups are the ResponseWriter and Request names.
+	reGoHandlerSig = regexp.MustCompile(`func\s*(?:\([^)]*\)\s*)?\w*\s*\(\s*(\w+)\s+http\.ResponseWriter\s*,\s*(\w+)\s+\*http\.Request\s*\)`)
+	// Express responses that write a body, and how a handler sets the type.
+	reJSResponseWrite  = regexp.MustCompile(`\bres\.(?:send|write|end)\s*\(`)
+	reJSContentTypeSet = regexp.MustCompile(`(?i)\bres\.(?:type|contentType)\s*\(\s*['"]([^'"]*)['"]|\bres\.(?:set|setHeader|header)\s*\(\s*['"]content-type['"]\s*,\s*['"]([^'"]*)['"]`)
+)
+
+// reflectedWrite is a response write of request-derived content and the
+// Content-Type in effect: "missing" or the suspect value that was set.
+type reflectedWrite struct {
+	line        int
+	contentType string
+}
+
+// reflectedWrites finds handlers that write request-derived content without
+// setting a Content-Type first, or after setting text/html. Go net/http
+// handlers are the canonical case; Express handlers are checked too, since
+// res.send of a string defaults to text/html. tainted holds variables
+// assigned from request input. At most one write is reported per handler.
+func reflectedWrites(lines []string, defs map[string]int, ext string, tainted map[string]bool) []reflectedWrite {
+	var out []reflectedWrite
+	for i, line := range lines {
+		switch ext {
+		case ".go":
+			m := reGoHandlerSig.FindStringSubmatch(line)
+			if len(m) < 3 {
+				continue
+			}
+			if w, ok := goReflectedWrite(braceBlock(lines, i), m[1], m[2], tainted); ok {
+				w.line += i
+				out = append(out, w)
+			}
+		case ".js", ".ts", ".jsx", ".tsx":
+			if method, _, _ := extractRoute(line, ext); method == "" || method == "MOUNT" {
+				continue
+			}
+			if w, ok := jsReflectedWrite(handlerBody(lines, defs, i, ext), tainted); ok {
+				w.line += i
+				out = append(out, w)
+			}
+		}
+	}
+	return out
+}
+
+// goReflectedWrite checks one Go handler body. The returned line is an
+// offset into body, starting at 1.
+func goReflectedWrite(body []string, w, r string, tainted map[string]bool) (reflectedWrite, bool) {
+	w, r = regexp.QuoteMeta(w), regexp.QuoteMeta(r)
+	reSet := regexp.MustCompile(`(?i)\b` + w + `\.Header\(\)\.(?:Set|Add)\(\s*"content-type"\s*,\s*"([^"]*)"`)
+	reWrite := regexp.MustCompile(`\b` + w + `\.Write\(|fmt\.Fprint\w*\(\s*` + w + `\s*,|io\.WriteString\(\s*` + w + `\s*,`)
+	reRequest := regexp.MustCompile(`\b` + r + `\.(?:URL|Form|PostForm|Header|Body|FormValue|PostFormValue|PathValue)\b`)
+
+	contentType := ""
+	for j, line := range body {
+		if m := reSet.FindStringSubmatch(line); len(m) > 1 {
+			contentType = m[1]
+		}
+		if !reWrite.MatchString(line) {
+			continue
+		}
+		if !reRequest.MatchString(line) && !reRequestInput.MatchString(line) && !usesTainted(line, tainted) {
+			continue
+		}
+		if ct := suspectContentType(contentType); ct != "" {
+			return reflectedWrite{line: j + 1, contentType: ct}, true
+		}
+	}
+	return reflectedWrite{}, false
+}
+
+// jsReflectedWrite checks one Express handler body. The returned line is an
+// offset into body, starting at 1.
+func jsReflectedWrite(body []string, tainted map[string]bool) (reflectedWrite, bool) {
+	contentType := ""
+	for j, line := range body {
+		if m := reJSContentTypeSet.FindStringSubmatch(line); m != nil {
+			contentType = m[1] + m[2]
+		}
+		loc := reJSResponseWrite.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		arg := firstCallArgument(line[loc[1]:])
+		if !reRequestInput.MatchString(arg) && !usesTainted(arg, tainted) {
+			continue
+		}
+		if ct := suspectContentType(contentType); ct != "" {
+			return reflectedWrite{line: j + 1, contentType: ct}, true
+		}
+	}
+	return reflectedWrite{}, false
+}
+
+// suspectContentType classifies the Content-Type set before a reflected
+// write: "missing" when none was set, the value when it renders as HTML,
+// or "" when it is safe.
+func suspectContentType(contentType string) string {
+	switch {
+	case contentType == "":
+		return "missing"
+	case strings.Contains(strings.ToLower(contentType), "html"):
+		return contentType
+	}
+	return ""
+}
+
+// usesTainted reports whether s mentions any identifier in tainted.
+func usesTainted(s string, tainted map[string]bool) bool {
+	for _, ident := range reIdentifier.FindAllString(s, -1) {
+		if tainted[ident] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Co-occurring risk signals ---
+
+// minCorrelatedSignals is how many distinct risk rules must coincide on one
+// endpoint or line before ATTACK-060 is emitted.
+const minCorrelatedSignals = 2
+
+// nonRiskRules are rules that describe inventory or scan state rather than
+// risk, so they never count toward ATTACK-060.
+var nonRiskRules = map[string]bool{
+	"ATTACK-000": true,
+	"ATTACK-001": true,
+	"ATTACK-060": true,
+}
+
+// riskGroup is a set of findings that concern the same endpoint or line.
+type riskGroup struct {
+	first    *pluginv1.Finding
+	endpoint string
+	rules    map[string]bool
+	critical bool
+}
+
+// correlateRisk groups the findings emitted so far by normalized endpoint
+// (or by file and line when no endpoint is known) and emits an ATTACK-060
+// finding for each group where two or more distinct risk rules coincide,
+// e.g. an unauthenticated admin route that accepts uploads.
+func correlateRisk(resp *sdk.ResponseBuilder) {
+	findings := resp.Build().GetFindings()
+
+	// Map each line that defines an endpoint to that endpoint, so that
+	// line-level signals (uploads, WebSockets) join the endpoint's group.
+	lineEndpoints := make(map[string]string)
+	for _, f := range findings {
+		if ep := f.GetMetadata()["endpoint"]; ep != "" {
+			lineEndpoints[lineKey(f)] = ep
+		}
+	}
+
+	groups := make(map[string]*riskGroup)
+	var order []string
+	for _, f := range findings {
+		if nonRiskRules[f.GetRuleId()] || f.GetSeverity() == sdk.SeverityInfo {
+			continue
+		}
+		endpoint := f.GetMetadata()["endpoint"]
+		if endpoint == "" {
+			endpoint = lineEndpoints[lineKey(f)]
+		}
+		key := "line:" + lineKey(f)
+		if endpoint != "" {
+			key = "endpoint:" + normalizeEndpoint(endpoint)
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &riskGroup{first: f, endpoint: endpoint, rules: make(map[string]bool)}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.rules[f.GetRuleId()] = true
+		if f.GetSeverity() == sdk.SeverityCritical {
+			g.critical = true
+		}
+	}
+
+	for _, key := range order {
+		g := groups[key]
+		if len(g.rules) < minCorrelatedSignals {
+			continue
+		}
+		rules := make([]string, 0, len(g.rules))
+		for id := range g.rules {
+			rules = append(rules, id)
+		}
+		sort.Strings(rules)
+
+		rule := rulesByID["ATTACK-060"]
+		severity := rule.Severity
+		if g.critical {
+			severity = sdk.SeverityCritical
+		}
+		subject := fmt.Sprintf("line %d", g.first.GetLocation().GetStartLine())
+		if g.endpoint != "" {
+			subject = g.endpoint
+		}
+		loc := g.first.GetLocation()
+		f := resp.Finding(
+			rule.ID,
+			severity,
+			rule.Confidence,
+			fmt.Sprintf("%d risk signals coincide on %s: %s", len(rules), subject, strings.Join(rules, ", ")),
+		).
+			At(loc.GetFilePath(), int(loc.GetStartLine()), int(loc.GetEndLine())).
+			WithMetadata("rules", strings.Join(rules, ","))
+		if g.endpoint != "" {
+			f.WithMetadata("endpoint", g.endpoint)
+		}
+		f.Done()
+	}
+}
+
+// lineKey identifies the file and line a finding points at.
+func lineKey(f *pluginv1.Finding) string {
+	return fmt.Sprintf("%s:%d", f.GetLocation().GetFilePath(), f.GetLocation().GetStartLine())
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Credentialed CORS with any origin ---
+
+// corsBlockLines bounds how far enclosingBlock looks for the brackets
+// around a CORS setting.
+const corsBlockLines = 200
+
+var (
+	// reCORSCredentials matches CORS settings that allow credentials.
+	reCORSCredentials = regexp.MustCompile(`(?i)\bcredentials\s*:\s*true\b|\bAllowCredentials\s*(?::\s*true\b|\(\s*\))|\b(?:supports|allow)_credentials\s*=\s*True\b|\ballowCredentials\s*(?:\(\s*true\s*\)|=\s*"true")|Access-Control-Allow-Credentials["']\s*,\s*["']?true|\bCORS_ALLOW_CREDENTIALS\s*=\s*True\b`)
+
+	// reCORSWildcardOrigin matches CORS settings that allow every origin.
+	reCORSWildcardOrigin = regexp.MustCompile(`(?i)\borigins?\s*[:=]\s*(?:\[\s*)?["']\*["']|\borigin\s*:\s*true\b|\bAllowedOrigins\s*:\s*\[\]string\{\s*"\*"|\bAllowAllOrigins\s*:\s*true\b|\ballow_origins\s*=\s*\[\s*["']\*["']|\ballow_origin_regex\s*=\s*r?["']\.\*|\ballowedOrigin(?:Pattern)?s\s*\(\s*"\*"|\.AllowAnyOrigin\s*\(|\bCORS_(?:ALLOW_ALL_ORIGINS|ORIGIN_ALLOW_ALL)\s*=\s*True\b|Access-Control-Allow-Origin["']\s*,\s*["']\*`)
+
+	// reCORSReflectedOrigin matches CORS settings that echo the request's
+	// Origin header or accept every origin through a callback.
+	reCORSReflectedOrigin = regexp.MustCompile(`(?i)Access-Control-Allow-Origin["']\s*,\s*(?:req\.headers?\.origin|req\.headers\[["']origin["']\]|req\.get\(\s*["']origin|r\.Header\.Get\(\s*"Origin"|request\.headers\.get\(\s*["']origin|request\.headers\[["']origin|origin\b)|\bAllowOriginFunc\s*:\s*func\s*\([^)]*\)\s*bool\s*\{\s*return\s+true\b|\bSetIsOriginAllowed\s*\(\s*\(?\s*\w*\s*\)?\s*=>\s*true\b|\borigin\s*:\s*\(?\s*\w+\s*,\s*\w+\s*\)?\s*=>\s*\{?\s*\w+\(\s*null\s*,\s*true|\borigin\s*:\s*function\s*\([^)]*\)\s*\{\s*\w+\(\s*null\s*,\s*true`)
+
+	// reFlaskCORS and reFlaskCORSOrigins recognize flask-cors, whose
+	// origins default to "*".
+	reFlaskCORS        = regexp.MustCompile(`\bCORS\s*\(`)
+	reFlaskCORSOrigins = regexp.MustCompile(`\b(?:origins|resources)\s*=`)
+)
+
+// enclosingBlock returns the lines of the innermost bracketed group around
+// column col of lines[i]: the object, argument list, or function body a
+// setting belongs to. Settings outside any group belong to the whole file.
+func enclosingBlock(lines []string, i, col int) []string {
+	start, depth := -1, 0
+	for j := i; j >= 0 && j >= i-corsBlockLines && start < 0; j-- {
+		k := len(lines[j]) - 1
+		if j == i {
+			k = col - 1
+		}
+		for ; k >= 0 && start < 0; k-- {
+			switch lines[j][k] {
+			case ')', '}', ']':
+				depth++
+			case '(', '{', '[':
+				if depth == 0 {
+					start, col = j, k
+				} else {
+					depth--
+				}
+			}
+		}
+	}
+	if start < 0 {
+		return lines
+	}
+	depth = 0
+	for j := start; j < len(lines) && j <= start+corsBlockLines; j++ {
+		line := lines[j]
+		if j == start {
+			line = line[col:]
+		}
+		depth += strings.Count(line, "(") + strings.Count(line, "{") + strings.Count(line, "[") -
+			strings.Count(line, ")") - strings.Count(line, "}") - strings.Count(line, "]")
+		if depth <= 0 {
+			return lines[start : j+1]
+		}
+	}
+	return lines[start:]
+}
+
+// corsAnyOrigin returns how text lets any origin make credentialed
+// requests ("wildcard" or "reflected") and the matching setting.
+func corsAnyOrigin(text string) (origin, setting string) {
+	if m := reCORSReflectedOrigin.FindString(text); m != "" {
+		return "reflected", m
+	}
+	if m := reCORSWildcardOrigin.FindString(text); m != "" {
+		return "wildcard", m
+	}
+	if reFlaskCORS.MatchString(text) && !reFlaskCORSOrigins.MatchString(text) {
+		return "wildcard", "CORS() default origins"
+	}
+	return "", ""
+}
+
+// reportCORSCredentials emits ATTACK-093 when lines[i] allows credentialed
+// CORS requests and its config block allows any origin. The block of a
+// setting is the group enclosing it; a response header is set by
+// statements, whose block is the group enclosing the line.
+func reportCORSCredentials(resp *sdk.ResponseBuilder, filePath string, lines []string, i int) {
+	loc := reCORSCredentials.FindStringIndex(lines[i])
+	if loc == nil {
+		return
+	}
+	credentials := lines[i][loc[0]:loc[1]]
+	col := loc[0]
+	if strings.HasPrefix(strings.ToLower(credentials), "access-control-") {
+		col = 0
+	}
+	origin, setting := corsAnyOrigin(strings.Join(enclosingBlock(lines, i, col), "\n"))
+	if origin == "" {
+		return
+	}
+	newFinding(
+		resp,
+		"ATTACK-093",
+		fmt.Sprintf("CORS allows credentials (%s) for a %s origin (%s)", credentials, origin, setting),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("origin", origin).
+		WithMetadata("origin_setting", setting).
+		WithMetadata("credentials_setting", credentials).
+		Done()
+}
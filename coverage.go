@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Extractor coverage report ---
+
+// frameworkImport recognizes a web framework import.
+type frameworkImport struct {
+	framework string
+	re        *regexp.Regexp
+}
+
+// frameworkImports lists server frameworks whose files are expected to
+// register routes. Plain net/http is omitted because HTTP clients import it
+// too.
+var frameworkImports = []frameworkImport{
+	{"express", regexp.MustCompile(`require\(\s*["']express["']\s*\)|from\s+["']express["']`)},
+	{"fastify", regexp.MustCompile(`require\(\s*["']fastify["']\s*\)|from\s+["']fastify["']`)},
+	{"koa", regexp.MustCompile(`["'](?:@koa/router|koa-router)["']`)},
+	{"hapi", regexp.MustCompile(`["']@hapi/hapi["']`)},
+	{"nestjs", regexp.MustCompile(`["']@nestjs/common["']`)},
+	{"flask", regexp.MustCompile(`^\s*(?:from\s+flask\s+import|import\s+flask\b)`)},
+	{"fastapi", regexp.MustCompile(`^\s*from\s+fastapi\s+import`)},
+	{"django", regexp.MustCompile(`^\s*from\s+django\.(?:urls|conf\.urls)\s+import`)},
+	{"gin", regexp.MustCompile(`"github\.com/gin-gonic/gin"`)},
+	{"echo", regexp.MustCompile(`"github\.com/labstack/echo(?:/v\d+)?"`)},
+	{"chi", regexp.MustCompile(`"github\.com/go-chi/chi(?:/v\d+)?"`)},
+	{"gorilla/mux", regexp.MustCompile(`"github\.com/gorilla/mux"`)},
+	{"fiber", regexp.MustCompile(`"github\.com/gofiber/fiber(?:/v\d+)?"`)},
+	{"ktor", regexp.MustCompile(`^\s*import\s+io\.ktor\.server\.routing`)},
+	{"spring", regexp.MustCompile(`^\s*import\s+org\.springframework\.web\.bind\.annotation`)},
+	{"aspnetcore", regexp.MustCompile(`^\s*using\s+Microsoft\.AspNetCore\.Mvc\s*;`)},
+}
+
+// importedFramework returns the framework imported on line, or "".
+func importedFramework(line string) string {
+	for _, fi := range frameworkImports {
+		if fi.re.MatchString(line) {
+			return fi.framework
+		}
+	}
+	return ""
+}
+
+// fileCoverage records what the extractors made of one source file.
+type fileCoverage struct {
+	path       string
+	framework  string
+	importLine int
+	endpoints  int
+}
+
+// coverageTracker collects per-file framework imports and endpoint counts
+// so that files importing a framework without yielding endpoints can be
+// reported as likely extractor gaps.
+type coverageTracker struct {
+	files []fileCoverage
+}
+
+// record adds the result of scanning one source file.
+func (c *coverageTracker) record(path, framework string, importLine, endpoints int) {
+	c.files = append(c.files, fileCoverage{path: path, framework: framework, importLine: importLine, endpoints: endpoints})
+}
+
+// merge adds the files other recorded after c's.
+func (c *coverageTracker) merge(other *coverageTracker) {
+	c.files = append(c.files, other.files...)
+}
+
+// report emits an ATTACK-000 coverage summary at the workspace root,
+// counting endpoints and findings by confidence, followed by one ATTACK-000
+// per file that imports a framework but produced no endpoints.
+func (c *coverageTracker) report(resp *sdk.ResponseBuilder, workspaceRoot string) {
+	endpoints, frameworkFiles, endpointFiles := 0, 0, 0
+	var gaps []fileCoverage
+	for _, f := range c.files {
+		endpoints += f.endpoints
+		if f.endpoints > 0 {
+			endpointFiles++
+		}
+		if f.framework == "" {
+			continue
+		}
+		frameworkFiles++
+		if f.endpoints == 0 {
+			gaps = append(gaps, f)
+		}
+	}
+
+	byConfidence := make(map[string]int)
+	for _, f := range resp.Build().GetFindings() {
+		if f.GetRuleId() != "ATTACK-000" {
+			byConfidence[confidenceNames[f.GetConfidence()]]++
+		}
+	}
+
+	newFinding(
+		resp,
+		"ATTACK-000",
+		fmt.Sprintf("Endpoint coverage: %d endpoint(s) in %d of %d source file(s); %d framework file(s) without endpoints",
+			endpoints, endpointFiles, len(c.files), len(gaps)),
+	).
+		At(workspaceRoot, 0, 0).
+		WithMetadata("coverage", "summary").
+		WithMetadata("files_scanned", strconv.Itoa(len(c.files))).
+		WithMetadata("framework_files", strconv.Itoa(frameworkFiles)).
+		WithMetadata("files_with_endpoints", strconv.Itoa(endpointFiles)).
+		WithMetadata("endpoints", strconv.Itoa(endpoints)).
+		WithMetadata("confidence_high", strconv.Itoa(byConfidence["high"])).
+		WithMetadata("confidence_medium", strconv.Itoa(byConfidence["medium"])).
+		WithMetadata("confidence_low", strconv.Itoa(byConfidence["low"])).
+		WithMetadata("gap_files", strconv.Itoa(len(gaps))).
+		Done()
+
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i].path < gaps[j].path })
+	for _, g := range gaps {
+		newFinding(
+			resp,
+			"ATTACK-000",
+			fmt.Sprintf("Endpoint coverage gap: imports %s but no endpoints were extracted", g.framework),
+		).
+			At(g.path, g.importLine, g.importLine).
+			WithMetadata("coverage", "gap").
+			WithMetadata("framework", g.framework).
+			Done()
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Hardcoded cloud credentials ---
+
+// credentialConfigExtensions are non-source files that commonly carry
+// inline credentials and are scanned for ATTACK-063 and ATTACK-086 only.
+var credentialConfigExtensions = map[string]bool{
+	".json":       true,
+	".yaml":       true,
+	".yml":        true,
+	".properties": true,
+	".env":        true,
+	".ini":        true,
+	".toml":       true,
+	".cfg":        true,
+	".tf":         true,
+}
+
+// cloudCredentialPatterns match provider-specific credentials. Group 1 is
+// the secret, which is redacted before it is reported.
+var cloudCredentialPatterns = []struct {
+	provider   string
+	credential string
+	re         *regexp.Regexp
+}{
+	{"aws", "access key ID", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"aws", "secret access key", regexp.MustCompile(`(?i)aws_?secret_?access_?key\\?["']?\s*[:=]\s*\\?["']?([A-Za-z0-9/+=]{40})\b`)},
+	{"gcp", "service account private key", regexp.MustCompile(`\\?"private_key\\?"\s*:\s*\\?"(-----BEGIN (?:RSA )?PRIVATE KEY-----[^"\\]*)`)},
+	{"azure", "storage account key", regexp.MustCompile(`(?i)\bAccountKey=([A-Za-z0-9+/=]{20,})`)},
+	{"azure", "shared access key", regexp.MustCompile(`(?i)\bSharedAccessKey=([A-Za-z0-9+/=]{20,})`)},
+}
+
+// isCredentialConfig reports whether name is a config file scanned for
+// cloud credentials.
+func isCredentialConfig(name string) bool {
+	return credentialConfigExtensions[filepath.Ext(name)] || strings.HasPrefix(name, ".env")
+}
+
+// cloudCredential returns the provider, credential type, and redacted
+// secret of the first cloud credential on line, or empty strings.
+// Documentation placeholders containing EXAMPLE are ignored.
+func cloudCredential(line string) (provider, credential, redacted string) {
+	for _, p := range cloudCredentialPatterns {
+		m := p.re.FindStringSubmatch(line)
+		if len(m) < 2 || strings.Contains(m[1], "EXAMPLE") {
+			continue
+		}
+		return p.provider, p.credential, redactSecret(m[1])
+	}
+	return "", "", ""
+}
+
+// redactSecret keeps a short prefix of secret for triage and masks the rest.
+func redactSecret(secret string) string {
+	if len(secret) < 12 {
+		return "****"
+	}
+	return secret[:4] + "****"
+}
+
+// reportCloudCredential emits ATTACK-063 when line embeds a cloud
+// credential and reports whether it did.
+func reportCloudCredential(resp *sdk.ResponseBuilder, filePath string, lineNum int, line string) bool {
+	provider, credential, redacted := cloudCredential(line)
+	if provider == "" {
+		return false
+	}
+	newFinding(
+		resp,
+		"ATTACK-063",
+		fmt.Sprintf("Hardcoded %s %s (%s)", strings.ToUpper(provider), credential, redacted),
+	).
+		At(filePath, lineNum, lineNum).
+		WithMetadata("provider", provider).
+		WithMetadata("credential", credential).
+		WithMetadata("redacted", redacted).
+		Done()
+	return true
+}
+
+// scanCredentialConfig checks a config file for ATTACK-063 and ATTACK-086.
+// A returned error means the file could not be read.
+func scanCredentialConfig(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	for i, line := range lines {
+		reportCloudCredential(resp, filePath, i+1, line)
+		reportBasicAuthCredential(resp, filePath, lines, i)
+	}
+	return nil
+}
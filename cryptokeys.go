@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Hardcoded encryption keys and IVs ---
+
+// cryptoArg matches a key or IV argument: a string or byte-string literal,
+// a literal converted to bytes, or an identifier that may be bound to a
+// literal elsewhere in the file.
+const cryptoArg = `\[\]byte\(\s*"[^"]*"\s*\)|b?"[^"]*"|b?'[^']*'|"[^"]*"\.toByteArray\(\)|Encoding\.\w+\.GetBytes\(\s*"[^"]*"\s*\)|[A-Za-z_][\w.]*`
+
+// cryptoCall builds a call pattern, replacing KEY and IV with named
+// argument groups.
+func cryptoCall(pattern string) *regexp.Regexp {
+	pattern = strings.ReplaceAll(pattern, "KEY", `(?P<key>`+cryptoArg+`)`)
+	pattern = strings.ReplaceAll(pattern, "IV", `(?P<iv>`+cryptoArg+`)`)
+	return regexp.MustCompile(pattern)
+}
+
+// cryptoCalls match cipher and MAC construction. algorithm names the
+// primitive; a non-empty alg group refines it (e.g. the hash of an HMAC).
+var cryptoCalls = []struct {
+	algorithm string
+	re        *regexp.Regexp
+}{
+	{"AES", cryptoCall(`\baes\.NewCipher\s*\(\s*KEY`)},
+	{"DES", cryptoCall(`\bdes\.NewCipher\s*\(\s*KEY`)},
+	{"3DES", cryptoCall(`\bdes\.NewTripleDESCipher\s*\(\s*KEY`)},
+	{"ChaCha20-Poly1305", cryptoCall(`\bchacha20poly1305\.NewX?\s*\(\s*KEY`)},
+	{"HMAC", cryptoCall(`\bhmac\.New\s*\(\s*(?P<alg>\w+)\.New\s*,\s*KEY`)},
+	{"CBC", cryptoCall(`\bcipher\.NewCBC(?:En|De)crypter\s*\(\s*\w+\s*,\s*IV`)},
+	{"", cryptoCall(`\bcreate(?:Cipher|Decipher)iv\s*\(\s*['"](?P<alg>[\w-]+)['"]\s*,\s*KEY\s*,\s*IV`)},
+	{"HMAC", cryptoCall(`\bcreateHmac\s*\(\s*['"](?P<alg>\w+)['"]\s*,\s*KEY`)},
+	{"", cryptoCall(`\bCryptoJS\.(?P<alg>AES|DES|TripleDES|Rabbit|RC4)\.(?:en|de)crypt\s*\([^,]+,\s*KEY`)},
+	{"Fernet", cryptoCall(`\bFernet\s*\(\s*KEY`)},
+	{"", cryptoCall(`\b(?P<alg>AES|DES3?|ChaCha20|Blowfish)\.new\s*\(\s*KEY(?:\s*,\s*\w+\.MODE_\w+\s*,\s*(?:(?:iv|nonce)\s*=\s*)?IV)?`)},
+	{"HMAC", cryptoCall(`\bhmac\.new\s*\(\s*KEY\s*,[^)]*\bhashlib\.(?P<alg>\w+)`)},
+	{"", cryptoCall(`\bSecretKeySpec\s*\(\s*KEY\s*,\s*"(?P<alg>[\w/-]+)"`)},
+	{"IV", cryptoCall(`\b(?:IvParameterSpec|GCMParameterSpec)\s*\(\s*(?:\d+\s*,\s*)?IV`)},
+	{"", cryptoCall(`\bnew\s+(?P<alg>HMAC(?:SHA\d+|MD5))\s*\(\s*KEY`)},
+}
+
+var (
+	// reCryptoLiteralBinding matches an identifier bound to a literal.
+	// Group 1 is the name and group 2 the literal expression.
+	reCryptoLiteralBinding = regexp.MustCompile(`\b(\w+)\s*(?::=|=)\s*(\[\]byte\(\s*"[^"]*"\s*\)|b?"[^"]*"|b?'[^']*'|"[^"]*"\.toByteArray\(\)|Encoding\.\w+\.GetBytes\(\s*"[^"]*"\s*\))\s*[;,)]?\s*$`)
+
+	// reCryptoLiteral extracts the string from a literal expression.
+	reCryptoLiteral = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+)
+
+// cryptoLiteralBindings returns the identifiers bound to literals in
+// lines, mapped to the literal's value.
+func cryptoLiteralBindings(lines []string) map[string]string {
+	bindings := make(map[string]string)
+	for _, line := range lines {
+		if m := reCryptoLiteralBinding.FindStringSubmatch(line); m != nil {
+			bindings[m[1]] = literalValue(m[2])
+		}
+	}
+	return bindings
+}
+
+// literalValue returns the string inside a literal expression.
+func literalValue(expr string) string {
+	m := reCryptoLiteral.FindStringSubmatch(expr)
+	if m == nil {
+		return ""
+	}
+	return m[1] + m[2]
+}
+
+// staticCryptoValue returns the literal an argument evaluates to, and
+// whether it is one.
+func staticCryptoValue(arg string, bindings map[string]string) (string, bool) {
+	if arg == "" {
+		return "", false
+	}
+	value, ok := literalValue(arg), reCryptoLiteral.MatchString(arg)
+	if !ok {
+		value, ok = bindings[arg]
+	}
+	if !ok || value == "" || reCredentialPlaceholder.MatchString(value) {
+		return "", false
+	}
+	return value, true
+}
+
+// hardcodedCryptoMaterial is a cipher or MAC built from literal material.
+type hardcodedCryptoMaterial struct {
+	algorithm string
+	// material lists what is static: "key", "iv", or both.
+	material []string
+	// redacted is the first static value, masked.
+	redacted string
+}
+
+// hardcodedCrypto returns the cipher or MAC on line whose key or IV is a
+// literal, directly or through an identifier in bindings.
+func hardcodedCrypto(line string, bindings map[string]string) (hardcodedCryptoMaterial, bool) {
+	for _, c := range cryptoCalls {
+		m := c.re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		var h hardcodedCryptoMaterial
+		algorithm := c.algorithm
+		for i, name := range c.re.SubexpNames() {
+			switch name {
+			case "alg":
+				if m[i] == "" {
+					continue
+				}
+				if algorithm == "" {
+					algorithm = strings.ToUpper(m[i])
+				} else {
+					algorithm += "-" + strings.ToUpper(m[i])
+				}
+			case "key", "iv":
+				if value, ok := staticCryptoValue(m[i], bindings); ok {
+					h.material = append(h.material, name)
+					if h.redacted == "" {
+						h.redacted = redactSecret(value)
+					}
+				}
+			}
+		}
+		if len(h.material) == 0 {
+			continue
+		}
+		h.algorithm = algorithm
+		return h, true
+	}
+	return hardcodedCryptoMaterial{}, false
+}
+
+// reportHardcodedCrypto emits ATTACK-090 when lines[i] constructs a cipher
+// or MAC from a literal key or IV. The material is redacted.
+func reportHardcodedCrypto(resp *sdk.ResponseBuilder, filePath string, lines []string, i int, bindings map[string]string) {
+	h, ok := hardcodedCrypto(lines[i], bindings)
+	if !ok {
+		return
+	}
+	what := "key"
+	switch strings.Join(h.material, ",") {
+	case "iv":
+		what = "IV"
+	case "key,iv":
+		what = "key and IV"
+	}
+	newFinding(
+		resp,
+		"ATTACK-090",
+		fmt.Sprintf("Hardcoded %s in %s construction (%s)", what, h.algorithm, h.redacted),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("algorithm", h.algorithm).
+		WithMetadata("material", strings.Join(h.material, ",")).
+		WithMetadata("redacted", h.redacted).
+		Done()
+}
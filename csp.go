@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Content-Security-Policy ---
+
+var (
+	// CSP configuration: helmet (enables a default policy), manual headers,
+	// django-csp, flask-talisman, and Spring Security.
+	reCSPConfig = regexp.MustCompile(`(?i)\bhelmet\s*\(|Content-Security-Policy|contentSecurityPolicy|CSPMiddleware|\bCSP_[A-Z_]+\s*=|CONTENT_SECURITY_POLICY|Talisman\s*\(`)
+
+	// CSP explicitly turned off.
+	reCSPDisabled = regexp.MustCompile(`contentSecurityPolicy\s*:\s*false|content_security_policy\s*=\s*None|contentSecurityPolicy\s*\(\s*\)\s*\.disable\(\)`)
+
+	// Directive names in header strings (script-src), helmet (scriptSrc),
+	// and django-csp settings (CSP_SCRIPT_SRC, "script-src").
+	reCSPDirective = regexp.MustCompile(`(?i)(?:\b|_)(default|script|style|img|connect|frame|object|font|media|worker|child|base)[-_]?src\b`)
+
+	// Unsafe keywords and wildcard sources for the directive preceding them.
+	reCSPUnsafeKeyword = regexp.MustCompile(`unsafe-(?:inline|eval)`)
+	reCSPWildcard      = regexp.MustCompile(`(?i)(?:\b|_)(?:default|script)[-_]?src\b["']?\s*[:=,]?\s*[\[(]?\s*["']?\*(?:["'\s;,\])]|$)`)
+
+	// Server-side HTML rendering, where a missing CSP matters.
+	reHTMLRendering = regexp.MustCompile(`\bres\.render\s*\(|\brender_template(?:_string)?\s*\(|\brender\s*\(\s*request\b|"html/template"|\bTemplateResponse\s*\(|\breturn\s+View\s*\(`)
+)
+
+// cspLookback is how many lines above a multi-line source list are searched
+// for the directive it belongs to.
+const cspLookback = 5
+
+// cspIssue is an unsafe or disabled CSP setting on one line.
+type cspIssue struct {
+	directive string
+	value     string
+	issue     string
+}
+
+// cspIssuesAt returns the CSP problems on lines[i]. Unsafe keywords are only
+// reported in files that configure CSP, so that unrelated strings do not
+// match; the directive is taken from the line or the lines above it.
+func cspIssuesAt(lines []string, i int, cspInFile bool) []cspIssue {
+	line := lines[i]
+	if reCSPDisabled.MatchString(line) {
+		return []cspIssue{{directive: "*", value: strings.TrimSpace(reCSPDisabled.FindString(line)), issue: "disabled"}}
+	}
+	if !cspInFile || isCommentLine(line) {
+		return nil
+	}
+
+	var issues []cspIssue
+	if m := reCSPWildcard.FindString(line); m != "" {
+		issues = append(issues, cspIssue{directive: cspDirectiveName(reCSPDirective.FindString(m)), value: "*", issue: "wildcard"})
+	}
+	for _, kw := range reCSPUnsafeKeyword.FindAllString(line, -1) {
+		issues = append(issues, cspIssue{directive: cspDirectiveBefore(lines, i, kw), value: "'" + kw + "'", issue: kw})
+	}
+	return issues
+}
+
+// cspDirectiveBefore returns the directive nearest before kw on lines[i],
+// or on the closest line above that names one.
+func cspDirectiveBefore(lines []string, i int, kw string) string {
+	line := lines[i]
+	if idx := strings.Index(line, kw); idx >= 0 {
+		if all := reCSPDirective.FindAllString(line[:idx], -1); len(all) > 0 {
+			return cspDirectiveName(all[len(all)-1])
+		}
+	}
+	for j := i - 1; j >= 0 && j >= i-cspLookback; j-- {
+		if all := reCSPDirective.FindAllString(lines[j], -1); len(all) > 0 {
+			return cspDirectiveName(all[len(all)-1])
+		}
+	}
+	return "unknown"
+}
+
+// cspDirectiveName normalizes scriptSrc, SCRIPT_SRC, and script-src to
+// script-src.
+func cspDirectiveName(s string) string {
+	m := reCSPDirective.FindStringSubmatch(s)
+	if m == nil {
+		return "unknown"
+	}
+	return strings.ToLower(m[1]) + "-src"
+}
+
+// cspTracker records, across the workspace, whether any CSP is configured
+// and where HTML is first rendered, to report apps without a policy.
+type cspTracker struct {
+	configured bool
+	renderFile string
+	renderLine int
+}
+
+// observe updates the tracker with one source line.
+func (t *cspTracker) observe(line, file string, lineNum int) {
+	if reCSPConfig.MatchString(line) && !reCSPDisabled.MatchString(line) {
+		t.configured = true
+	}
+	if t.renderFile == "" && reHTMLRendering.MatchString(line) {
+		t.renderFile, t.renderLine = file, lineNum
+	}
+}
+
+// merge adds what other observed after t's files.
+func (t *cspTracker) merge(other *cspTracker) {
+	t.configured = t.configured || other.configured
+	if t.renderFile == "" {
+		t.renderFile, t.renderLine = other.renderFile, other.renderLine
+	}
+}
+
+// report emits ATTACK-073 at the first HTML rendering site when no scanned
+// file configures a Content-Security-Policy.
+func (t *cspTracker) report(resp *sdk.ResponseBuilder) {
+	if t.configured || t.renderFile == "" {
+		return
+	}
+	newFinding(
+		resp,
+		"ATTACK-073",
+		"Server renders HTML but no Content-Security-Policy is configured",
+	).
+		At(t.renderFile, t.renderLine, t.renderLine).
+		WithMetadata("issue", "missing").
+		Done()
+}
+
+// reportCSPIssues emits ATTACK-073 for each unsafe or disabled CSP setting
+// on lines[i].
+func reportCSPIssues(resp *sdk.ResponseBuilder, filePath string, lines []string, i int, cspInFile bool) {
+	for _, c := range cspIssuesAt(lines, i, cspInFile) {
+		message := fmt.Sprintf("Content-Security-Policy %s allows %s", c.directive, c.value)
+		if c.issue == "disabled" {
+			message = fmt.Sprintf("Content-Security-Policy disabled (%s)", c.value)
+		}
+		newFinding(resp, "ATTACK-073", message).
+			At(filePath, i+1, i+1).
+			WithMetadata("directive", c.directive).
+			WithMetadata("value", c.value).
+			WithMetadata("issue", c.issue).
+			Done()
+	}
+}
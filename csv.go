@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- CSV export ---
+
+// csvHeader lists the exported columns in order.
+var csvHeader = []string{"code", "severity", "confidence", "file", "line", "endpoint", "method", "message"}
+
+var severityNames = map[pluginv1.Severity]string{
+	sdk.SeverityCritical: "critical",
+	sdk.SeverityHigh:     "high",
+	sdk.SeverityMedium:   "medium",
+	sdk.SeverityLow:      "low",
+	sdk.SeverityInfo:     "info",
+}
+
+var confidenceNames = map[pluginv1.Confidence]string{
+	sdk.ConfidenceHigh:   "high",
+	sdk.ConfidenceMedium: "medium",
+	sdk.ConfidenceLow:    "low",
+}
+
+// writeFindingsCSV writes findings to path as CSV with a header row. Files
+// are written relative to workspaceRoot; endpoint and method are empty for
+// findings that do not concern a single route.
+func writeFindingsCSV(path, workspaceRoot string, findings []*pluginv1.Finding) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		file := finding.GetLocation().GetFilePath()
+		if rel, err := filepath.Rel(workspaceRoot, file); err == nil {
+			file = filepath.ToSlash(rel)
+		}
+		md := finding.GetMetadata()
+		record := []string{
+			finding.GetRuleId(),
+			severityNames[finding.GetSeverity()],
+			confidenceNames[finding.GetConfidence()],
+			file,
+			strconv.Itoa(int(finding.GetLocation().GetStartLine())),
+			md["endpoint"],
+			md["method"],
+			finding.GetMessage(),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
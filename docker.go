@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Dockerfile surface hints ---
+
+var (
+	reDockerExpose     = regexp.MustCompile(`(?i)^\s*EXPOSE\s+(.+)$`)
+	reDockerEntrypoint = regexp.MustCompile(`(?i)^\s*(?:CMD|ENTRYPOINT)\s+(.+)$`)
+)
+
+// debugPorts maps well-known remote debugger ports to the debugger.
+var debugPorts = map[string]string{
+	"2345": "delve",
+	"5005": "jdwp",
+	"5678": "debugpy",
+	"9229": "node-inspector",
+}
+
+// isDockerfile reports whether name is a Dockerfile (Dockerfile,
+// Dockerfile.prod, api.dockerfile).
+func isDockerfile(name string) bool {
+	lower := strings.ToLower(name)
+	return lower == "dockerfile" || strings.HasPrefix(lower, "dockerfile.") || strings.HasSuffix(lower, ".dockerfile")
+}
+
+// scanDockerfile records the ports a Dockerfile exposes and flags remote
+// debugger ports (ATTACK-053). The service is named after the directory
+// containing the Dockerfile. A returned error means the file could not be
+// read.
+func scanDockerfile(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+
+	service := filepath.Base(filepath.Dir(filePath))
+
+	type exposedPort struct {
+		port string
+		line int
+	}
+	var exposed []exposedPort
+	entrypoint := ""
+	for i, line := range lines {
+		if m := reDockerExpose.FindStringSubmatch(line); len(m) > 1 {
+			for _, field := range strings.Fields(m[1]) {
+				port, _, _ := strings.Cut(field, "/")
+				exposed = append(exposed, exposedPort{port: port, line: i + 1})
+			}
+		}
+		if m := reDockerEntrypoint.FindStringSubmatch(line); len(m) > 1 {
+			entrypoint = strings.TrimSpace(m[1])
+		}
+	}
+
+	ports := make([]string, 0, len(exposed))
+	for _, p := range exposed {
+		ports = append(ports, p.port)
+	}
+
+	for _, p := range exposed {
+		debugger, ok := debugPorts[p.port]
+		if !ok {
+			continue
+		}
+		f := newFinding(
+			resp,
+			"ATTACK-053",
+			fmt.Sprintf("Debug port %s (%s) exposed by service %s", p.port, debugger, service),
+		).
+			At(filePath, p.line, p.line).
+			WithMetadata("port", p.port).
+			WithMetadata("debugger", debugger).
+			WithMetadata("service", service).
+			WithMetadata("exposed_ports", strings.Join(ports, ","))
+		if entrypoint != "" {
+			f.WithMetadata("entrypoint", entrypoint)
+		}
+		f.Done()
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Duplicate (shadowed) route detection ---
+
+// routeLocation is where a route was registered.
+type routeLocation struct {
+	endpoint string
+	file     string
+	line     int
+}
+
+// routeKey identifies a route within a service by method and normalized path.
+type routeKey struct {
+	service  string
+	method   string
+	endpoint string
+}
+
+// routeRegistry collects route registrations across files to find
+// method+path pairs registered more than once in the same service.
+type routeRegistry struct {
+	root         string
+	serviceDepth int
+	routes       map[routeKey][]routeLocation
+}
+
+func newRouteRegistry(root string, serviceDepth int) *routeRegistry {
+	return &routeRegistry{root: root, serviceDepth: serviceDepth, routes: make(map[routeKey][]routeLocation)}
+}
+
+// add records a route. Mounts are skipped because the same prefix is
+// routinely mounted several times with different middleware.
+func (r *routeRegistry) add(method, endpoint, file string, line int) {
+	if method == "" || method == "MOUNT" {
+		return
+	}
+	key := routeKey{
+		service:  serviceFor(r.root, file, r.serviceDepth),
+		method:   method,
+		endpoint: normalizeEndpoint(endpoint),
+	}
+	r.routes[key] = append(r.routes[key], routeLocation{endpoint: endpoint, file: file, line: line})
+}
+
+// merge adds the routes other recorded after r's.
+func (r *routeRegistry) merge(other *routeRegistry) {
+	for key, locs := range other.routes {
+		r.routes[key] = append(r.routes[key], locs...)
+	}
+}
+
+// reportDuplicates emits ATTACK-058 at every location of a route that is
+// registered more than once, listing all locations in metadata.
+func (r *routeRegistry) reportDuplicates(resp *sdk.ResponseBuilder) {
+	keys := make([]routeKey, 0, len(r.routes))
+	for k, locs := range r.routes {
+		if len(locs) > 1 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	for _, k := range keys {
+		locs := r.routes[k]
+		all := make([]string, len(locs))
+		for i, l := range locs {
+			all[i] = fmt.Sprintf("%s:%d", l.file, l.line)
+		}
+		for _, l := range locs {
+			newFinding(
+				resp,
+				"ATTACK-058",
+				fmt.Sprintf("Route %s %s registered %d times in service %s", k.method, l.endpoint, len(locs), k.service),
+			).
+				At(l.file, l.line, l.line).
+				WithMetadata("endpoint", l.endpoint).
+				WithMetadata("method", k.method).
+				WithMetadata("service", k.service).
+				WithMetadata("locations", strings.Join(all, ",")).
+				Done()
+		}
+	}
+}
+
+// serviceFor names the service a file belongs to: the first depth
+// directories of its path relative to root, or "." when depth is zero or
+// the file sits above that depth.
+func serviceFor(root, file string, depth int) string {
+	if depth <= 0 {
+		return "."
+	}
+	rel, err := filepath.Rel(root, filepath.Dir(file))
+	if err != nil || rel == "." {
+		return "."
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < depth {
+		return "."
+	}
+	return strings.Join(parts[:depth], "/")
+}
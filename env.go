@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// --- Environment variable expansion in path inputs ---
+
+// pathInputs are the inputs holding file or directory paths, which may
+// reference environment variables as $VAR or ${VAR}.
+var pathInputs = []string{
+	"workspace_root",
+	"baseline_path",
+	"inventory_output",
+	"csv_output",
+	"manifest_output",
+	"progress_output",
+	"suppressions",
+	"diff_hunks",
+}
+
+// expandPathInputs returns a copy of input with environment variables
+// expanded in every string-valued path input. A reference to an unset
+// variable is an error, so that a missing CI variable cannot silently turn
+// workspace_root into "" and produce an empty scan.
+func expandPathInputs(input map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(input))
+	for k, v := range input {
+		out[k] = v
+	}
+	for _, name := range pathInputs {
+		s, ok := input[name].(string)
+		if !ok {
+			continue
+		}
+		expanded, err := expandEnv(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = expanded
+	}
+	return out, nil
+}
+
+// expandEnv expands $VAR and ${VAR} in s from the process environment,
+// failing on variables that are not set.
+func expandEnv(s string) (string, error) {
+	var missing []string
+	expanded := os.Expand(s, func(name string) string {
+		v, ok := os.LookupEnv(name)
+		if !ok && !containsString(missing, name) {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined environment variable(s) %s in %q", "$"+strings.Join(missing, ", $"), s)
+	}
+	return expanded, nil
+}
+
+// envReferences returns the names of the environment variables s
+// references as $VAR or ${VAR}, in order of first use.
+func envReferences(s string) []string {
+	var names []string
+	os.Expand(s, func(name string) string {
+		if !containsString(names, name) {
+			names = append(names, name)
+		}
+		return ""
+	})
+	return names
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Scan errors and diagnostics ---
+
+// scanErrorKind classifies a problem encountered during a scan.
+type scanErrorKind string
+
+const (
+	// errKindWalk is a failure to enter or list a directory.
+	errKindWalk scanErrorKind = "walk"
+	// errKindRead is a failure to open or read a file.
+	errKindRead scanErrorKind = "read"
+	// errKindParse is a file that was read but could not be parsed,
+	// e.g. a line longer than the scanner buffer or malformed config.
+	errKindParse scanErrorKind = "parse"
+	// errKindConfig is an invalid entry in the workspace config file; the
+	// entry is ignored and the rest of the file applies.
+	errKindConfig scanErrorKind = "config"
+	// errKindGit is a failed git invocation; the scan falls back to the
+	// full workspace.
+	errKindGit scanErrorKind = "git"
+	// errKindTimeout is a file abandoned after per_file_timeout, or a scan
+	// stopped after scan_timeout with partial results.
+	errKindTimeout scanErrorKind = "timeout"
+	// errKindWebhook is a webhook_url batch that could not be delivered
+	// after retries; the scan continues and the response is complete.
+	errKindWebhook scanErrorKind = "webhook"
+)
+
+// scanErrorKinds lists kinds in reporting order.
+var scanErrorKinds = []scanErrorKind{errKindWalk, errKindRead, errKindParse, errKindConfig, errKindGit, errKindTimeout, errKindWebhook}
+
+// scanError is a non-fatal error tied to a path in the workspace.
+type scanError struct {
+	Kind scanErrorKind
+	Path string
+	Err  error
+}
+
+func (e *scanError) Error() string {
+	return fmt.Sprintf("%s error: %s: %v", e.Kind, e.Path, e.Err)
+}
+
+func (e *scanError) Unwrap() error { return e.Err }
+
+// maxDiagnosticPaths caps how many paths are listed per error kind.
+const maxDiagnosticPaths = 10
+
+// errorCollector accumulates non-fatal scan errors so that one unreadable
+// file does not abort the scan or disappear silently.
+type errorCollector struct {
+	errs []*scanError
+}
+
+// add records err against path. Read errors that are really parse
+// failures (bufio.ErrTooLong) or deadlines are reclassified.
+func (c *errorCollector) add(kind scanErrorKind, path string, err error) {
+	if c == nil || err == nil {
+		return
+	}
+	if kind == errKindRead && errors.Is(err, bufio.ErrTooLong) {
+		kind = errKindParse
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		kind = errKindTimeout
+	}
+	c.errs = append(c.errs, &scanError{Kind: kind, Path: path, Err: err})
+}
+
+// merge adds the errors other collected after c's.
+func (c *errorCollector) merge(other *errorCollector) {
+	c.errs = append(c.errs, other.errs...)
+}
+
+// counts returns the number of errors per kind.
+func (c *errorCollector) counts() map[scanErrorKind]int {
+	counts := make(map[scanErrorKind]int)
+	for _, e := range c.errs {
+		counts[e.Kind]++
+	}
+	return counts
+}
+
+// report emits one ATTACK-000 diagnostic finding per error kind with the
+// count, the first error message, and a sample of affected paths.
+func (c *errorCollector) report(resp *sdk.ResponseBuilder) {
+	counts := c.counts()
+	for _, kind := range scanErrorKinds {
+		n := counts[kind]
+		if n == 0 {
+			continue
+		}
+		var first *scanError
+		var paths []string
+		for _, e := range c.errs {
+			if e.Kind != kind {
+				continue
+			}
+			if first == nil {
+				first = e
+			}
+			if len(paths) < maxDiagnosticPaths {
+				paths = append(paths, e.Path)
+			}
+		}
+		newFinding(
+			resp,
+			"ATTACK-000",
+			fmt.Sprintf("Scan diagnostics: %d %s error(s)", n, kind),
+		).
+			At(first.Path, 0, 0).
+			WithMetadata("error_type", string(kind)).
+			WithMetadata("count", strconv.Itoa(n)).
+			WithMetadata("first_error", first.Err.Error()).
+			WithMetadata("paths", strings.Join(paths, ",")).
+			Done()
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Per-file attack surface scores ---
+
+// fileScoreComponents are the counted signals, in reporting order.
+var fileScoreComponents = []string{"endpoints", "unauthenticated", "uploads", "websockets", "injection_sinks"}
+
+// defaultFileScoreWeights weigh each component of the per-file score.
+// Unauthenticated endpoints and injection sinks dominate because they are
+// the findings most likely to be exploitable on their own.
+var defaultFileScoreWeights = map[string]float64{
+	"endpoints":       1,
+	"unauthenticated": 3,
+	"uploads":         2,
+	"websockets":      2,
+	"injection_sinks": 4,
+}
+
+// parseFileScores reads the file_scores input. It accepts true to enable
+// scoring with the default weights, or an object mapping components to
+// weights to enable scoring with overrides. The returned map is nil when
+// scoring is disabled.
+func parseFileScores(v any) (map[string]float64, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !t {
+			return nil, nil
+		}
+		return defaultFileScoreWeights, nil
+	case map[string]any:
+		weights := make(map[string]float64, len(defaultFileScoreWeights))
+		for k, w := range defaultFileScoreWeights {
+			weights[k] = w
+		}
+		for component, raw := range t {
+			if _, ok := defaultFileScoreWeights[component]; !ok {
+				return nil, fmt.Errorf("unknown file_scores component %q (want one of %s)", component, strings.Join(fileScoreComponents, ", "))
+			}
+			weight, ok := raw.(float64)
+			if !ok || weight < 0 {
+				return nil, fmt.Errorf("file_scores weight for %s must be a non-negative number", component)
+			}
+			weights[component] = weight
+		}
+		return weights, nil
+	default:
+		return nil, fmt.Errorf("file_scores must be a boolean or an object, got %T", v)
+	}
+}
+
+// fileScoreComponent returns the score component a finding counts toward,
+// or "" if it does not contribute.
+func fileScoreComponent(ruleID string, metadata map[string]string) string {
+	switch ruleID {
+	case "ATTACK-001":
+		if metadata["drift"] == "removed" || metadata["source"] == "template" {
+			return ""
+		}
+		return "endpoints"
+	case "ATTACK-002":
+		return "unauthenticated"
+	case "ATTACK-004":
+		return "uploads"
+	case "ATTACK-005":
+		return "websockets"
+	}
+	if rulesByID[ruleID].Category == categoryInjection {
+		return "injection_sinks"
+	}
+	return ""
+}
+
+// scoreFiles emits ATTACK-077 for every file with a non-zero weighted score.
+// Each finding carries its rank, 1 being the highest score, so the output
+// doubles as a review order.
+func scoreFiles(resp *sdk.ResponseBuilder, weights map[string]float64) {
+	type fileScore struct {
+		file   string
+		counts map[string]int
+		score  float64
+	}
+	byFile := make(map[string]*fileScore)
+	for _, f := range resp.Build().GetFindings() {
+		component := fileScoreComponent(f.GetRuleId(), f.GetMetadata())
+		file := f.GetLocation().GetFilePath()
+		if component == "" || file == "" {
+			continue
+		}
+		s, ok := byFile[file]
+		if !ok {
+			s = &fileScore{file: file, counts: make(map[string]int)}
+			byFile[file] = s
+		}
+		s.counts[component]++
+		s.score += weights[component]
+	}
+
+	scores := make([]*fileScore, 0, len(byFile))
+	for _, s := range byFile {
+		if s.score > 0 {
+			scores = append(scores, s)
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].score != scores[j].score {
+			return scores[i].score > scores[j].score
+		}
+		return scores[i].file < scores[j].file
+	})
+
+	for rank, s := range scores {
+		parts := make([]string, 0, len(fileScoreComponents))
+		for _, c := range fileScoreComponents {
+			if n := s.counts[c]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", n, strings.ReplaceAll(c, "_", " ")))
+			}
+		}
+		score := strconv.FormatFloat(s.score, 'f', -1, 64)
+		f := newFinding(
+			resp,
+			"ATTACK-077",
+			fmt.Sprintf("Attack surface score %s (%s)", score, strings.Join(parts, ", ")),
+		).
+			At(s.file, 0, 0).
+			WithMetadata("score", score).
+			WithMetadata("rank", strconv.Itoa(rank+1))
+		for _, c := range fileScoreComponents {
+			f.WithMetadata(c, strconv.Itoa(s.counts[c]))
+		}
+		f.Done()
+	}
+}
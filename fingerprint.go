@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Stable finding fingerprints ---
+
+// fingerprintBytes is how much of the SHA-256 digest a fingerprint keeps.
+const fingerprintBytes = 16
+
+// fingerprinter computes finding fingerprints that survive line shifts:
+// a hash of the rule, the file relative to the workspace root, the
+// normalized endpoint, and the content of the finding's line instead of
+// its number, plus the HTTP method for findings that have one, since one
+// line can register several (Rails resources). Findings without a
+// readable line hash their message.
+type fingerprinter struct {
+	root string
+	// file and lines cache the last file read, since findings arrive
+	// grouped by file; nil lines mark an unreadable file.
+	file  string
+	lines []string
+}
+
+func newFingerprinter(root string) *fingerprinter {
+	return &fingerprinter{root: root}
+}
+
+// fingerprint returns the hex fingerprint of f. Paths may be absolute or
+// relative to the root, so it is the same before and after relativizing.
+func (p *fingerprinter) fingerprint(f *pluginv1.Finding) string {
+	file := f.GetLocation().GetFilePath()
+	abs := file
+	if file != "" && !filepath.IsAbs(file) {
+		abs = filepath.Join(p.root, file)
+	} else if rel, err := filepath.Rel(p.root, file); err == nil {
+		file = rel
+	}
+	endpoint := ""
+	if ep := f.GetMetadata()["endpoint"]; ep != "" {
+		endpoint = normalizeEndpoint(ep)
+	}
+	parts := []string{f.GetRuleId(), filepath.ToSlash(file), endpoint, p.context(f, abs)}
+	if method := f.GetMetadata()["method"]; method != "" {
+		parts = append(parts, method)
+	}
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:fingerprintBytes])
+}
+
+// context returns the whitespace-collapsed text of f's start line, or its
+// message when the line cannot be read.
+func (p *fingerprinter) context(f *pluginv1.Finding, abs string) string {
+	line := int(f.GetLocation().GetStartLine())
+	if abs == "" || line < 1 {
+		return f.GetMessage()
+	}
+	if abs != p.file {
+		p.file = abs
+		p.lines, _ = readLines(abs)
+	}
+	if line > len(p.lines) {
+		return f.GetMessage()
+	}
+	return strings.Join(strings.Fields(p.lines[line-1]), " ")
+}
+
+// tag sets the fingerprint metadata of every finding in out.
+func (p *fingerprinter) tag(out *pluginv1.InvokeToolResponse) {
+	for _, f := range out.GetFindings() {
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string)
+		}
+		f.Metadata["fingerprint"] = p.fingerprint(f)
+	}
+}
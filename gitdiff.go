@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// --- Incremental scanning from a git diff range ---
+
+// gitDiffRange is the base and head refs of the git_diff input.
+type gitDiffRange struct {
+	base string
+	head string
+}
+
+// parseGitDiff reads the git_diff input: an object with a required "base"
+// and an optional "head" (default HEAD). It returns nil when unset.
+func parseGitDiff(v any) (*gitDiffRange, error) {
+	if v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("git_diff must be an object with base and head refs, got %T", v)
+	}
+	r := &gitDiffRange{head: "HEAD"}
+	r.base, _ = m["base"].(string)
+	if head, _ := m["head"].(string); head != "" {
+		r.head = head
+	}
+	if r.base == "" {
+		return nil, errors.New("git_diff.base is required")
+	}
+	if strings.HasPrefix(r.base, "-") || strings.HasPrefix(r.head, "-") {
+		return nil, errors.New("git_diff refs must not start with '-'")
+	}
+	return r, nil
+}
+
+// changedFiles returns the absolute paths of files changed between the
+// base and head refs, limited to the workspace root.
+func (r *gitDiffRange) changedFiles(ctx context.Context, workspaceRoot string) (map[string]bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", workspaceRoot, "diff", "--name-only", "--relative", r.base+".."+r.head)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git diff %s..%s: %s", r.base, r.head, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git diff %s..%s: %w", r.base, r.head, err)
+	}
+
+	files := make(map[string]bool)
+	for _, name := range strings.Split(string(out), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			files[filepath.Join(workspaceRoot, filepath.FromSlash(name))] = true
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- GraphQL mutations without auth ---
+
+var (
+	// Schema (SDL) mutation types, including gql`...` template literals.
+	reGraphQLMutationType = regexp.MustCompile(`^\s*(?:extend\s+)?type\s+Mutation\b`)
+	reGraphQLSDLField     = regexp.MustCompile(`^\s*(\w+)\s*[(:]`)
+
+	// Resolver maps: Mutation: { createUser: async (...) => { ... } }.
+	reGraphQLMutationMap = regexp.MustCompile(`^\s*Mutation\s*[:=]\s*\{`)
+	reGraphQLMapField    = regexp.MustCompile(`^\s*(?:async\s+)?(\w+)\s*(?::|\()`)
+
+	// gqlgen resolvers, strawberry and graphene mutations.
+	reGqlgenMutation     = regexp.MustCompile(`func\s*\(\s*\w+\s+\*mutationResolver\s*\)\s*(\w+)\s*\(`)
+	reStrawberryMutation = regexp.MustCompile(`@strawberry\.mutation\b`)
+	rePyDef              = regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)\s*\(`)
+	reGrapheneMutation   = regexp.MustCompile(`^\s*class\s+(\w+)\s*\(\s*(?:graphene\.)?Mutation\s*\)`)
+
+	// Auth directives, graphql-shield rules, and context checks.
+	reGraphQLAuth = regexp.MustCompile(`(?i)(?:@auth\b|@authenticated|@requiresAuth|@hasRole|@hasPermission|@isAuthenticated|@aws_cognito_user_pools|@aws_iam|@login_required|@permission_required|\bisAuthenticated\b|is_authenticated|requireAuth|requireUser|\bisAdmin\b|permission_classes|(?:context|ctx)\.(?:user|currentUser|auth|session)\b|info\.context\.user|ForContext\s*\(|UserFromContext|AuthenticationError|ForbiddenError)`)
+)
+
+// graphqlMutation is a mutation field and whether any of its definitions
+// (schema field, resolver, shield rule) carries an auth check.
+type graphqlMutation struct {
+	name   string
+	line   int
+	authed bool
+}
+
+// graphqlMutationSet collects mutations by name so that a field declared in
+// the schema and implemented in a resolver map is reported once.
+type graphqlMutationSet struct {
+	byName map[string]*graphqlMutation
+	order  []string
+}
+
+// add records a definition of mutation name at line.
+func (s *graphqlMutationSet) add(name string, line int, authed bool) {
+	if s.byName == nil {
+		s.byName = make(map[string]*graphqlMutation)
+	}
+	m, ok := s.byName[name]
+	if !ok {
+		m = &graphqlMutation{name: name, line: line}
+		s.byName[name] = m
+		s.order = append(s.order, name)
+	}
+	m.authed = m.authed || authed
+}
+
+// reportGraphQLMutations emits ATTACK-065 for every mutation in lines whose
+// schema field, resolver, and shield rule all lack an auth check.
+func reportGraphQLMutations(resp *sdk.ResponseBuilder, filePath, ext string, lines []string) {
+	var set graphqlMutationSet
+	collectSDLMutations(&set, lines)
+	collectResolverMapMutations(&set, lines)
+	switch ext {
+	case ".go":
+		for i, line := range lines {
+			if m := reGqlgenMutation.FindStringSubmatch(line); len(m) > 1 {
+				set.add(m[1], i+1, anyLineMatches(braceBlock(lines, i), reGraphQLAuth))
+			}
+		}
+	case ".py":
+		collectPythonMutations(&set, lines)
+	}
+
+	for _, name := range set.order {
+		m := set.byName[name]
+		if m.authed {
+			continue
+		}
+		newFinding(
+			resp,
+			"ATTACK-065",
+			fmt.Sprintf("GraphQL mutation %s has no auth directive or check", m.name),
+		).
+			At(filePath, m.line, m.line).
+			WithMetadata("mutation", m.name).
+			Done()
+	}
+}
+
+// collectSDLMutations reads fields of type Mutation { ... } blocks. A
+// directive on the type applies to every field; multi-line argument lists
+// are attributed to the field they belong to.
+func collectSDLMutations(set *graphqlMutationSet, lines []string) {
+	for i := range lines {
+		if !reGraphQLMutationType.MatchString(lines[i]) {
+			continue
+		}
+		typeAuthed := reGraphQLAuth.MatchString(lines[i])
+		parens := 0
+		current := ""
+		for j := i + 1; j < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[j]), "}"); j++ {
+			line := lines[j]
+			if m := reGraphQLSDLField.FindStringSubmatch(line); parens == 0 && len(m) > 1 {
+				current = m[1]
+				set.add(current, j+1, typeAuthed)
+			}
+			if current != "" && reGraphQLAuth.MatchString(line) {
+				set.add(current, j+1, true)
+			}
+			parens += strings.Count(line, "(") - strings.Count(line, ")")
+		}
+	}
+}
+
+// collectResolverMapMutations reads fields of Mutation: { ... } objects,
+// which cover both resolver maps and graphql-shield permission maps.
+func collectResolverMapMutations(set *graphqlMutationSet, lines []string) {
+	for i := range lines {
+		if !reGraphQLMutationMap.MatchString(lines[i]) {
+			continue
+		}
+		depth := strings.Count(lines[i], "{") - strings.Count(lines[i], "}")
+		for j := i + 1; j < len(lines) && depth > 0; j++ {
+			line := lines[j]
+			if m := reGraphQLMapField.FindStringSubmatch(line); depth == 1 && len(m) > 1 {
+				body := []string{line}
+				if strings.Contains(line, "{") {
+					body = braceBlock(lines, j)
+				}
+				set.add(m[1], j+1, anyLineMatches(body, reGraphQLAuth))
+			}
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+		}
+	}
+}
+
+// collectPythonMutations reads strawberry @strawberry.mutation methods and
+// graphene Mutation subclasses. Decorators count toward the auth check.
+func collectPythonMutations(set *graphqlMutationSet, lines []string) {
+	defs := handlerDefinitions(lines)
+	for i, line := range lines {
+		if m := reGrapheneMutation.FindStringSubmatch(line); len(m) > 1 {
+			set.add(m[1], i+1, anyLineMatches(handlerBody(lines, defs, i, ".py"), reGraphQLAuth))
+			continue
+		}
+		if !reStrawberryMutation.MatchString(line) {
+			continue
+		}
+		for j := i + 1; j < len(lines) && j <= i+5; j++ {
+			if m := rePyDef.FindStringSubmatch(lines[j]); len(m) > 1 {
+				body := append(lines[i:j:j], handlerBody(lines, defs, j, ".py")...)
+				set.add(m[1], j+1, anyLineMatches(body, reGraphQLAuth))
+				break
+			}
+		}
+	}
+}
+
+// braceBlock returns lines from start through the line that closes the
+// first brace opened at or after start.
+func braceBlock(lines []string, start int) []string {
+	depth, opened := 0, false
+	for j := start; j < len(lines); j++ {
+		depth += strings.Count(lines[j], "{") - strings.Count(lines[j], "}")
+		if depth > 0 {
+			opened = true
+		}
+		if opened && depth <= 0 {
+			return lines[start : j+1]
+		}
+	}
+	return lines[start:]
+}
+
+// scanGraphQLSchema checks a standalone .graphql/.gql schema file for
+// ATTACK-065 and upload surface (ATTACK-004). A returned error means the
+// file could not be read.
+func scanGraphQLSchema(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	reportGraphQLMutations(resp, filePath, ".graphql", lines)
+	reportGraphQLSchemaUploads(resp, filePath, lines)
+	return nil
+}
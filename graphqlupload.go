@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- GraphQL file uploads ---
+
+// graphqlUploadMechanisms recognize GraphQL multipart upload support:
+// middleware, the Upload scalar, and server libraries.
+var graphqlUploadMechanisms = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"graphql-upload", regexp.MustCompile(`['"]graphql-upload(?:/[\w.-]+)?['"]|\bgraphqlUpload(?:Express|Koa)\s*\(|\bprocessRequest\s*\(\s*(?:req|ctx\.req)\b`)},
+	{"Upload scalar", regexp.MustCompile(`^\s*scalar\s+Upload\b|\bUpload\s*:\s*GraphQLUpload\b`)},
+	{"apollo-uploads", regexp.MustCompile(`^\s*uploads\s*:\s*(?:true|\{)`)},
+	{"graphene-file-upload", regexp.MustCompile(`\bgraphene_file_upload\b`)},
+	{"strawberry-upload", regexp.MustCompile(`\bstrawberry\.file_uploads\b`)},
+}
+
+var (
+	// reGraphQLUploadArg matches an argument of type Upload in SDL.
+	reGraphQLUploadArg = regexp.MustCompile(`\w+\s*:\s*\[?\s*Upload\b`)
+
+	// reGqlgenUpload matches gqlgen's upload argument type.
+	reGqlgenUpload = regexp.MustCompile(`\bgraphql\.Upload\b`)
+
+	// rePyUploadArg matches strawberry Upload parameters and graphene
+	// Upload() arguments.
+	rePyUploadArg = regexp.MustCompile(`:\s*(?:List\[|list\[)?\s*Upload\b|=\s*Upload\s*\(`)
+)
+
+// graphqlUpload is upload surface found on a line: a mutation field that
+// accepts a file, or the mechanism enabling uploads.
+type graphqlUpload struct {
+	mutation  string
+	mechanism string
+}
+
+// graphqlUploads returns the GraphQL upload surface in lines by line
+// index. A nil entry marks a line, such as a multi-line argument, that is
+// covered by the finding of the mutation it belongs to.
+func graphqlUploads(lines []string, ext string) map[int]*graphqlUpload {
+	uploads := make(map[int]*graphqlUpload)
+	for i, line := range lines {
+		for _, m := range graphqlUploadMechanisms {
+			if m.re.MatchString(line) {
+				uploads[i] = &graphqlUpload{mechanism: m.name}
+				break
+			}
+		}
+	}
+	collectSDLUploads(uploads, lines)
+	switch ext {
+	case ".go":
+		for i, line := range lines {
+			if m := reGqlgenMutation.FindStringSubmatch(line); len(m) > 1 && reGqlgenUpload.MatchString(line) {
+				uploads[i] = &graphqlUpload{mutation: m[1], mechanism: "gqlgen"}
+			}
+		}
+	case ".py":
+		collectPythonUploads(uploads, lines)
+	}
+	return uploads
+}
+
+// collectSDLUploads records Mutation fields with an Upload argument, in
+// schema files and gql`...` template literals alike.
+func collectSDLUploads(uploads map[int]*graphqlUpload, lines []string) {
+	for i := range lines {
+		if !reGraphQLMutationType.MatchString(lines[i]) {
+			continue
+		}
+		parens, current, currentLine := 0, "", 0
+		for j := i + 1; j < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[j]), "}"); j++ {
+			line := lines[j]
+			if m := reGraphQLSDLField.FindStringSubmatch(line); parens == 0 && len(m) > 1 {
+				current, currentLine = m[1], j
+			}
+			if current != "" && reGraphQLUploadArg.MatchString(line) {
+				if j != currentLine {
+					uploads[j] = nil
+				}
+				uploads[currentLine] = &graphqlUpload{mutation: current, mechanism: "Upload scalar"}
+			}
+			parens += strings.Count(line, "(") - strings.Count(line, ")")
+		}
+	}
+}
+
+// collectPythonUploads records strawberry mutations with an Upload
+// parameter and graphene Mutation subclasses with an Upload argument.
+func collectPythonUploads(uploads map[int]*graphqlUpload, lines []string) {
+	for i, line := range lines {
+		if !rePyUploadArg.MatchString(line) {
+			continue
+		}
+		if m := rePyDef.FindStringSubmatch(line); len(m) > 1 {
+			uploads[i] = &graphqlUpload{mutation: m[1], mechanism: "strawberry-upload"}
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			if m := reGrapheneMutation.FindStringSubmatch(lines[j]); len(m) > 1 {
+				uploads[i] = nil
+				uploads[j] = &graphqlUpload{mutation: m[1], mechanism: "graphene-file-upload"}
+				break
+			}
+			if strings.HasPrefix(lines[j], "class ") {
+				break
+			}
+		}
+	}
+}
+
+// reportGraphQLUpload emits ATTACK-004 for GraphQL upload surface on line,
+// flagged with graphql metadata so it can be told apart from HTTP
+// multipart handlers.
+func reportGraphQLUpload(resp *sdk.ResponseBuilder, filePath string, lineNum int, line string, u *graphqlUpload) {
+	message := fmt.Sprintf("GraphQL file upload support detected (%s): %s", u.mechanism, strings.TrimSpace(line))
+	if u.mutation != "" {
+		message = fmt.Sprintf("GraphQL mutation %s accepts a file upload", u.mutation)
+	}
+	f := newFinding(resp, "ATTACK-004", message).
+		At(filePath, lineNum, lineNum).
+		WithMetadata("graphql", "true").
+		WithMetadata("mechanism", u.mechanism)
+	if u.mutation != "" {
+		f.WithMetadata("mutation", u.mutation)
+	}
+	f.Done()
+}
+
+// reportGraphQLSchemaUploads emits ATTACK-004 for the upload surface of a
+// standalone schema file.
+func reportGraphQLSchemaUploads(resp *sdk.ResponseBuilder, filePath string, lines []string) {
+	uploads := graphqlUploads(lines, ".graphql")
+	indexes := make([]int, 0, len(uploads))
+	for i, u := range uploads {
+		if u != nil {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+	for _, i := range indexes {
+		reportGraphQLUpload(resp, filePath, i+1, lines[i], uploads[i])
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- gRPC server transport and reflection ---
+
+// grpcServers recognize gRPC server setup per language. insecure matches
+// an explicit plaintext listener or credential. For libraries where TLS is
+// opt-in on the server builder, server matches the builder and secure the
+// TLS configuration; a server in a file with no secure match is plaintext.
+// interceptor matches the registration of server interceptors, where
+// gRPC services enforce authentication.
+var grpcServers = map[string]struct {
+	language    string
+	library     string
+	insecure    *regexp.Regexp
+	server      *regexp.Regexp
+	secure      *regexp.Regexp
+	interceptor *regexp.Regexp
+}{
+	".go": {
+		"go", "grpc-go",
+		regexp.MustCompile(`\bgrpc\.Creds\s*\(\s*insecure\.NewCredentials\s*\(`),
+		regexp.MustCompile(`\bgrpc\.NewServer\s*\(`),
+		regexp.MustCompile(`\bgrpc\.Creds\s*\(`),
+		regexp.MustCompile(`\bgrpc\.(?:Chain)?(?:Unary|Stream)Interceptor\s*\(`),
+	},
+	".py": {
+		"python", "grpcio",
+		regexp.MustCompile(`\.add_insecure_port\s*\(`),
+		nil, nil,
+		regexp.MustCompile(`\binterceptors\s*=`),
+	},
+	".js": {
+		"javascript", "grpc-js",
+		regexp.MustCompile(`\bServerCredentials\.createInsecure\s*\(`),
+		nil, nil,
+		regexp.MustCompile(`\binterceptors\s*:`),
+	},
+	".kt": {
+		"kotlin", "grpc-java",
+		nil,
+		regexp.MustCompile(`\b(?:Netty)?ServerBuilder\s*\.\s*forPort\s*\(`),
+		regexp.MustCompile(`\.useTransportSecurity\s*\(|\.sslContext\s*\(`),
+		regexp.MustCompile(`\.intercept\s*\(|\bServerInterceptors\.intercept\w*\s*\(`),
+	},
+	".cs": {
+		"csharp", "grpc-core",
+		regexp.MustCompile(`\bServerCredentials\.Insecure\b`),
+		nil, nil,
+		regexp.MustCompile(`\.Intercept\s*\(|\bInterceptors\.Add\w*\s*[<(]`),
+	},
+}
+
+// grpcReflection matches registration of the gRPC server reflection
+// service per language, with the library providing it.
+var grpcReflection = map[string][]struct {
+	library string
+	re      *regexp.Regexp
+}{
+	".go": {{"grpc-go", regexp.MustCompile(`\breflection\.Register\s*\(`)}},
+	".py": {{"grpcio-reflection", regexp.MustCompile(`\benable_server_reflection\s*\(`)}},
+	".js": {{"grpc-js-reflection", regexp.MustCompile(`\bnew\s+(?:\w+\.)?ReflectionService\s*\(`)}},
+	".kt": {{"grpc-java", regexp.MustCompile(`\bProtoReflectionService(?:V1)?\.newInstance\s*\(`)}},
+	".cs": {
+		{"grpc-dotnet", regexp.MustCompile(`\.MapGrpcReflectionService\s*\(`)},
+		{"grpc-core", regexp.MustCompile(`\bServerReflection\.BindService\s*\(`)},
+	},
+}
+
+// reportGRPCServers emits ATTACK-088 for gRPC servers that listen without
+// TLS credentials, noting servers that also register no interceptor to
+// authenticate calls, and ATTACK-089 for server reflection, raised to High
+// when the file also sets up a plaintext server.
+func reportGRPCServers(resp *sdk.ResponseBuilder, filePath, ext string, lines []string) {
+	if jsExtensions[ext] {
+		ext = ".js"
+	}
+	g, ok := grpcServers[ext]
+	if !ok {
+		return
+	}
+	hasSecure := g.secure != nil && anyLineMatches(lines, g.secure)
+	hasInterceptor := anyLineMatches(lines, g.interceptor)
+
+	insecure := false
+	for i, line := range lines {
+		mechanism := ""
+		switch {
+		case g.insecure != nil && g.insecure.MatchString(line):
+			mechanism = "insecure credentials"
+		case g.server != nil && !hasSecure && g.server.MatchString(line):
+			mechanism = "no credentials"
+		default:
+			continue
+		}
+		insecure = true
+		message := fmt.Sprintf("gRPC server (%s) serves plaintext RPC: %s", g.library, mechanism)
+		if !hasInterceptor {
+			message += ", and no auth interceptor is registered"
+		}
+		newFinding(resp, "ATTACK-088", message).
+			At(filePath, i+1, i+1).
+			WithMetadata("language", g.language).
+			WithMetadata("library", g.library).
+			WithMetadata("mechanism", mechanism).
+			WithMetadata("auth_interceptor", strconv.FormatBool(hasInterceptor)).
+			Done()
+	}
+
+	rule := rulesByID["ATTACK-089"]
+	for i, line := range lines {
+		for _, r := range grpcReflection[ext] {
+			if !r.re.MatchString(line) {
+				continue
+			}
+			severity := rule.Severity
+			message := fmt.Sprintf("gRPC server reflection enabled (%s): %s", r.library, strings.TrimSpace(line))
+			if insecure {
+				severity = sdk.SeverityHigh
+				message = fmt.Sprintf("gRPC server reflection enabled on a plaintext server (%s): %s", r.library, strings.TrimSpace(line))
+			}
+			resp.Finding(rule.ID, severity, rule.Confidence, message).
+				At(filePath, i+1, i+1).
+				WithMetadata("library", r.library).
+				WithMetadata("insecure_transport", strconv.FormatBool(insecure)).
+				Done()
+			break
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Client-supplied headers trusted for security decisions ---
+
+var (
+	// reHeaderReads match reads of a named request header. Group 1 is the
+	// header name.
+	reHeaderReads = []*regexp.Regexp{
+		regexp.MustCompile(`\bHeader\.Get\(\s*"([^"]+)"\s*\)`),
+		regexp.MustCompile(`\bHeaders?\[\s*["']([^"']+)["']\s*\]`),
+		regexp.MustCompile(`\bGetHeader\(\s*"([^"]+)"\s*\)`),
+		regexp.MustCompile(`\bheaders\s*\[\s*["']([^"']+)["']\s*\]`),
+		regexp.MustCompile(`\bheaders\.get\(\s*["']([^"']+)["']`),
+		regexp.MustCompile(`\b(?:req|request|ctx)\.(?:get|header)\(\s*["']([^"']+)["']\s*\)`),
+		regexp.MustCompile(`\bcall\.request\.header\(\s*"([^"]+)"\s*\)`),
+		regexp.MustCompile(`\bMETA\s*(?:\[\s*|\.get\(\s*)["'](HTTP_[A-Z0-9_]+)["']`),
+	}
+
+	// reTrustHeaderName matches headers that carry identity, role, client
+	// IP, or internal-only flags when set by a proxy, and that any client
+	// can set when the application is reachable directly.
+	reTrustHeaderName = regexp.MustCompile(`(?i)admin|user|role|group|permission|privilege|scope|tenant|internal|trusted|bypass|debug|forwarded-for|real-ip|client-ip|original-ip`)
+
+	// reNonTrustHeaderName matches headers that are legitimately checked:
+	// credentials the server verifies and standard request headers.
+	reNonTrustHeaderName = regexp.MustCompile(`(?i)^(?:authorization|proxy-authorization|user-agent|cookie)$|token|key|signature|secret|csrf|xsrf`)
+
+	// reBranchKeyword matches lines that branch on a condition.
+	reBranchKeyword = regexp.MustCompile(`^\s*(?:if|elif|else\s+if|unless|when|while)\b|\}\s*else\s+if\b`)
+
+	// reDecision matches comparisons and membership tests.
+	reDecision = regexp.MustCompile(`[=!]==?|\.(?:includes|contains|Contains|startswith|startsWith|StartsWith|HasPrefix)\(|\bin\s+[\w\[(]`)
+
+	// reEmptyCheck matches presence checks, such as falling back to the
+	// socket address when X-Forwarded-For is absent, which decide nothing.
+	reEmptyCheck = regexp.MustCompile(`[=!]==?\s*(?:""|''|\b(?:nil|None|null|undefined)\b)|(?:""|''|\b(?:nil|None|null|undefined)\b)\s*[=!]==?`)
+
+	// reHeaderVarAssign captures the variable a header read is assigned to.
+	reHeaderVarAssign = regexp.MustCompile(`\b([A-Za-z_]\w*)\s*(?::=|=)\s*[^=]`)
+)
+
+// headerName returns the header name as sent on the wire, converting
+// Django META keys such as HTTP_X_USER_ID to X-User-Id form.
+func headerName(raw string) string {
+	if !strings.HasPrefix(raw, "HTTP_") {
+		return raw
+	}
+	parts := strings.Split(strings.TrimPrefix(raw, "HTTP_"), "_")
+	for i, p := range parts {
+		parts[i] = p[:1] + strings.ToLower(p[1:])
+	}
+	return strings.Join(parts, "-")
+}
+
+// trustHeaderRead returns the first client-controllable trust header read
+// on line, or "".
+func trustHeaderRead(line string) string {
+	for _, re := range reHeaderReads {
+		for _, m := range re.FindAllStringSubmatch(line, -1) {
+			name := headerName(m[1])
+			if reTrustHeaderName.MatchString(name) && !reNonTrustHeaderName.MatchString(name) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// headerSignal classifies what a trusted header stands in for.
+func headerSignal(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "-ip") || strings.Contains(lower, "forwarded"):
+		return "client-ip"
+	case strings.Contains(lower, "internal") || strings.Contains(lower, "trusted") || strings.Contains(lower, "bypass") || strings.Contains(lower, "debug"):
+		return "internal-flag"
+	default:
+		return "identity"
+	}
+}
+
+// trustHeaderVars maps variables assigned from a trust header to the
+// header name, so that a later branch on the variable can be reported.
+// Assignments that already decide, such as isAdmin := h == "true", are
+// reported themselves and not tracked.
+func trustHeaderVars(lines []string) map[string]string {
+	vars := make(map[string]string)
+	for _, line := range lines {
+		name := trustHeaderRead(line)
+		if name == "" || trustHeaderBranch(line, nil) != "" {
+			continue
+		}
+		if m := reHeaderVarAssign.FindStringSubmatch(line); m != nil {
+			vars[m[1]] = name
+		}
+	}
+	return vars
+}
+
+// trustHeaderBranch returns the header a security decision on line is
+// based on, either read directly or through a variable from vars, or "".
+func trustHeaderBranch(line string, vars map[string]string) string {
+	stripped := reEmptyCheck.ReplaceAllString(line, "")
+	decides := reDecision.MatchString(stripped) || (stripped == line && reBranchKeyword.MatchString(line))
+	if !decides {
+		return ""
+	}
+	if name := trustHeaderRead(line); name != "" {
+		return name
+	}
+	for _, ident := range reIdentifier.FindAllString(stripped, -1) {
+		if name, ok := vars[ident]; ok {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Hunk-aware filtering of findings ---
+
+// reHunkHeader matches a unified diff hunk header and captures the start
+// and optional length of the new-file range.
+var reHunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// lineRange is an inclusive range of changed lines.
+type lineRange struct {
+	start int
+	end   int
+}
+
+// changedLines maps workspace-relative, slash-separated paths to the line
+// ranges changed in them.
+type changedLines map[string][]lineRange
+
+// parseDiffHunks reads the diff_hunks input. It accepts an object mapping
+// files to [start, end] ranges, a path to a unified diff, or true to
+// compute hunks from the git_diff range. It returns nil when unset.
+func parseDiffHunks(v any, diffRange *gitDiffRange) (changedLines, bool, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, false, nil
+	case bool:
+		if !t {
+			return nil, false, nil
+		}
+		if diffRange == nil {
+			return nil, false, errors.New("diff_hunks: true requires git_diff")
+		}
+		// Computed from git once the workspace is known.
+		return nil, true, nil
+	case string:
+		lines, err := readLines(t)
+		if err != nil {
+			return nil, false, fmt.Errorf("reading diff_hunks: %w", err)
+		}
+		return parseUnifiedDiff(lines), false, nil
+	case map[string]any:
+		hunks := make(changedLines, len(t))
+		for file, raw := range t {
+			ranges, ok := raw.([]any)
+			if !ok {
+				return nil, false, fmt.Errorf("diff_hunks[%q] must be an array of [start, end] ranges", file)
+			}
+			for _, r := range ranges {
+				pair, ok := r.([]any)
+				if !ok || len(pair) != 2 {
+					return nil, false, fmt.Errorf("diff_hunks[%q] must be an array of [start, end] ranges", file)
+				}
+				start, ok1 := pair[0].(float64)
+				end, ok2 := pair[1].(float64)
+				if !ok1 || !ok2 || start < 1 || end < start {
+					return nil, false, fmt.Errorf("diff_hunks[%q] has an invalid range %v", file, pair)
+				}
+				key := filepath.ToSlash(filepath.Clean(file))
+				hunks[key] = append(hunks[key], lineRange{start: int(start), end: int(end)})
+			}
+		}
+		return hunks, false, nil
+	default:
+		return nil, false, fmt.Errorf("diff_hunks must be an object, a diff file path, or true, got %T", v)
+	}
+}
+
+// parseUnifiedDiff extracts the new-file line ranges of every hunk. Paths
+// come from "+++ b/path" headers; deleted files and pure deletions are
+// skipped.
+func parseUnifiedDiff(lines []string) changedLines {
+	hunks := make(changedLines)
+	file := ""
+	for _, line := range lines {
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			name, _, _ = strings.Cut(name, "\t")
+			file = ""
+			if name != "/dev/null" {
+				file = strings.TrimPrefix(name, "b/")
+			}
+			continue
+		}
+		m := reHunkHeader.FindStringSubmatch(line)
+		if m == nil || file == "" {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		length := 1
+		if m[2] != "" {
+			length, _ = strconv.Atoi(m[2])
+		}
+		if length > 0 {
+			hunks[file] = append(hunks[file], lineRange{start: start, end: start + length - 1})
+		}
+	}
+	return hunks
+}
+
+// changedHunks runs git diff with no context lines for the range and
+// parses the result.
+func (r *gitDiffRange) changedHunks(ctx context.Context, workspaceRoot string) (changedLines, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", workspaceRoot, "diff", "-U0", "--relative", "--no-color", r.base+".."+r.head)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git diff %s..%s: %s", r.base, r.head, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git diff %s..%s: %w", r.base, r.head, err)
+	}
+	return parseUnifiedDiff(strings.Split(string(out), "\n")), nil
+}
+
+// files returns the absolute paths of the files with changes.
+func (c changedLines) files(workspaceRoot string) map[string]bool {
+	files := make(map[string]bool, len(c))
+	for file := range c {
+		files[filepath.Join(workspaceRoot, filepath.FromSlash(file))] = true
+	}
+	return files
+}
+
+// contains reports whether line of file (relative to the workspace) was
+// changed. Line 0 (file-level findings) counts as changed when the file is.
+func (c changedLines) contains(file string, line int) bool {
+	ranges, ok := c[file]
+	if !ok {
+		return false
+	}
+	if line == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if line >= r.start && line <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// apply removes findings outside the changed lines. Diagnostics are kept.
+func (c changedLines) apply(out *pluginv1.InvokeToolResponse, workspaceRoot string) {
+	kept := out.Findings[:0]
+	for _, f := range out.GetFindings() {
+		loc := f.GetLocation()
+		if f.GetRuleId() == "ATTACK-000" || c.contains(relativePath(workspaceRoot, loc.GetFilePath()), int(loc.GetStartLine())) {
+			kept = append(kept, f)
+		}
+	}
+	out.Findings = kept
+}
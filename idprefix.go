@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Finding ID prefix ---
+
+// defaultIDPrefix is the prefix of every rule ID in the catalog.
+const defaultIDPrefix = "ATTACK"
+
+var (
+	// reIDPrefix matches an acceptable id_prefix: a letter followed by
+	// letters, digits, or underscores.
+	reIDPrefix = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,31}$`)
+
+	// reRuleID matches catalog rule IDs inside messages and metadata.
+	reRuleID = regexp.MustCompile(`\b` + defaultIDPrefix + `-(\d{3})\b`)
+)
+
+// parseIDPrefix reads the id_prefix input. It returns "" when findings
+// keep the default prefix.
+func parseIDPrefix(v any) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	prefix, ok := v.(string)
+	if !ok || !reIDPrefix.MatchString(prefix) {
+		return "", fmt.Errorf("id_prefix must be 1-32 letters, digits, or underscores starting with a letter, got %v", v)
+	}
+	if prefix == defaultIDPrefix {
+		return "", nil
+	}
+	return prefix, nil
+}
+
+// applyIDPrefix replaces the ATTACK prefix with prefix in every rule ID,
+// and in the rule IDs that messages and metadata (correlated rules,
+// aggregated issues) refer to. Numeric suffixes are kept.
+func applyIDPrefix(out *pluginv1.InvokeToolResponse, prefix string) {
+	replacement := prefix + "-$1"
+	for _, f := range out.GetFindings() {
+		f.RuleId = reRuleID.ReplaceAllString(f.RuleId, replacement)
+		f.Message = reRuleID.ReplaceAllString(f.Message, replacement)
+		for k, v := range f.Metadata {
+			f.Metadata[k] = reRuleID.ReplaceAllString(v, replacement)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// --- Endpoint inventory and drift detection ---
+
+// reRouteParam matches path parameters across framework syntaxes:
+// :id, {id}, <int:id>, [id], and * wildcards.
+var reRouteParam = regexp.MustCompile(`:\w+|\{[^}]*\}|<[^>]*>|\[[^\]]*\]|\*+`)
+
+// inventoryEntry is one endpoint in an exported inventory.
+type inventoryEntry struct {
+	Endpoint  string `json:"endpoint"`
+	Pattern   string `json:"pattern,omitempty"`
+	Framework string `json:"framework,omitempty"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	// Source is "template" for endpoints referenced by template forms
+	// rather than registered by a route.
+	Source string `json:"source,omitempty"`
+}
+
+// inventory is the on-disk format of an endpoint inventory.
+type inventory struct {
+	Endpoints []inventoryEntry `json:"endpoints"`
+}
+
+// normalizeEndpoint rewrites framework-specific parameter syntax to a
+// common placeholder so that /users/:id and /users/{id} compare equal.
+func normalizeEndpoint(endpoint string) string {
+	n := reRouteParam.ReplaceAllString(endpoint, "{}")
+	if len(n) > 1 {
+		n = strings.TrimRight(n, "/")
+	}
+	return n
+}
+
+// endpointBaseline is a previously exported inventory that the current
+// scan is compared against.
+type endpointBaseline struct {
+	entries map[string]inventoryEntry
+	seen    map[string]bool
+}
+
+// loadBaseline reads an inventory file written by a previous scan.
+func loadBaseline(path string) (*endpointBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var inv inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	b := &endpointBaseline{
+		entries: make(map[string]inventoryEntry, len(inv.Endpoints)),
+		seen:    make(map[string]bool),
+	}
+	for _, e := range inv.Endpoints {
+		if e.Source != "" {
+			// Drift compares registered routes only.
+			continue
+		}
+		b.entries[normalizeEndpoint(e.Endpoint)] = e
+	}
+	return b, nil
+}
+
+// observe records that endpoint exists in the current scan and reports
+// whether it is new relative to the baseline.
+func (b *endpointBaseline) observe(endpoint string) bool {
+	key := normalizeEndpoint(endpoint)
+	b.seen[key] = true
+	_, existed := b.entries[key]
+	return !existed
+}
+
+// merge marks the endpoints other observed as seen.
+func (b *endpointBaseline) merge(other *endpointBaseline) {
+	for key := range other.seen {
+		b.seen[key] = true
+	}
+}
+
+// removed returns baseline endpoints that were not observed, sorted by path.
+func (b *endpointBaseline) removed() []inventoryEntry {
+	var out []inventoryEntry
+	for key, e := range b.entries {
+		if !b.seen[key] {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Endpoint < out[j].Endpoint })
+	return out
+}
+
+// inventoryRecorder collects endpoints during a scan for export.
+type inventoryRecorder struct {
+	root    string
+	entries []inventoryEntry
+}
+
+// record adds an endpoint, storing its file relative to the workspace root.
+// pattern is the original regex for routes simplified by simplifyRegexRoute
+// and framework names the extractor that matched.
+func (r *inventoryRecorder) record(endpoint, pattern, framework, filePath string, line int) {
+	r.entries = append(r.entries, inventoryEntry{Endpoint: endpoint, Pattern: pattern, Framework: framework, File: r.rel(filePath), Line: line})
+}
+
+// merge adds the endpoints other recorded after r's.
+func (r *inventoryRecorder) merge(other *inventoryRecorder) {
+	r.entries = append(r.entries, other.entries...)
+}
+
+// recordFrom adds an endpoint found somewhere other than a route
+// registration, such as a template form, tagged with its source.
+func (r *inventoryRecorder) recordFrom(source, endpoint, framework, filePath string, line int) {
+	r.entries = append(r.entries, inventoryEntry{Endpoint: endpoint, Framework: framework, File: r.rel(filePath), Line: line, Source: source})
+}
+
+// rel returns filePath relative to the workspace root, in slash form.
+func (r *inventoryRecorder) rel(filePath string) string {
+	if rel, err := filepath.Rel(r.root, filePath); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filePath
+}
+
+// write saves the collected inventory as JSON.
+func (r *inventoryRecorder) write(path string) error {
+	data, err := json.MarshalIndent(inventory{Endpoints: r.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
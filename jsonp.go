@@ -0,0 +1,85 @@
+package main
+
+import (
+	"regexp"
+)
+
+// --- JSONP callbacks ---
+
+// defaultJSONPCallback is the query parameter Express's res.jsonp and
+// Gin's c.JSONP read the callback name from.
+const defaultJSONPCallback = "callback"
+
+var (
+	// reJSONPHelper matches framework JSONP responders. Group 1 is the
+	// helper.
+	reJSONPHelper = regexp.MustCompile(`\b(res\.jsonp|c\.JSONP)\s*\(`)
+
+	// reJSONPCallbackName matches Express's setting renaming the callback
+	// parameter. Group 1 is the parameter.
+	reJSONPCallbackName = regexp.MustCompile(`\.set\(\s*['"]jsonp callback name['"]\s*,\s*['"]([\w-]+)['"]`)
+
+	// reJSONPWrapper matches a body built as name(data): concatenation with
+	// "(", template or f-string interpolation followed by "(", and
+	// "%s(%s)" or "{}({})" formats.
+	reJSONPWrapper = regexp.MustCompile(`\+\s*['"]\(['"]?\s*\+|\$?\{[\w.\[\]'"()]+\}\(|%[sv]\(%[sv]\)|\{\}\(\{\}\)`)
+
+	// reCallbackName matches request parameter names that carry a JSONP
+	// callback.
+	reCallbackName = regexp.MustCompile(`(?i)callback|jsonp|^cb$`)
+)
+
+// jsonpCallback returns the request parameter naming the JSONP callback
+// that lines[i] wraps its response in, with the helper or "concatenation"
+// building the wrapper, or empty strings. Manual wrappers count when the
+// callback is a callback-like request parameter, read on the line or
+// assigned earlier in the handler to a variable used on the line.
+func jsonpCallback(lines []string, starts []int, i int, ext string) (param, wrapper string) {
+	line := lines[i]
+	if m := reJSONPHelper.FindStringSubmatch(line); m != nil {
+		param = defaultJSONPCallback
+		if m[1] == "res.jsonp" {
+			for _, l := range lines {
+				if n := reJSONPCallbackName.FindStringSubmatch(l); n != nil {
+					param = n[1]
+				}
+			}
+		}
+		return param, m[1]
+	}
+	if !reJSONPWrapper.MatchString(line) {
+		return "", ""
+	}
+	if param := callbackParameter(line); param != "" {
+		return param, "concatenation"
+	}
+	start := starts[i]
+	if start < 0 {
+		start = max(0, i-optOutWindow)
+	}
+	for _, name := range reIdentifier.FindAllString(line, -1) {
+		assign := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\s*:?=[^=]`)
+		for k := i - 1; k >= start; k-- {
+			if loc := assign.FindStringIndex(lines[k]); loc != nil {
+				if param := callbackParameter(lines[k][loc[1]-1:]); param != "" {
+					return param, "concatenation"
+				}
+				break
+			}
+		}
+	}
+	return "", ""
+}
+
+// callbackParameter returns the first callback-like request parameter read
+// in s.
+func callbackParameter(s string) string {
+	for _, m := range reReflectedParam.FindAllStringSubmatch(s, -1) {
+		for _, g := range m[1:] {
+			if g != "" && reCallbackName.MatchString(g) {
+				return g
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Language filter ---
+
+// languageExtensions maps each language name accepted by the languages
+// input to its source extensions. Together they cover sourceExtensions.
+var languageExtensions = map[string][]string{
+	"go":         {".go"},
+	"python":     {".py"},
+	"javascript": {".js", ".jsx"},
+	"typescript": {".ts", ".tsx"},
+	"kotlin":     {".kt"},
+	"csharp":     {".cs"},
+	"ruby":       {".rb"},
+	"java":       {".java"},
+}
+
+// parseLanguages reads the languages input and returns the source
+// extensions to scan, or nil when every language is scanned.
+func parseLanguages(v any) (map[string]bool, error) {
+	names, err := parseStringList(v, "languages")
+	if err != nil || names == nil {
+		return nil, err
+	}
+	exts := make(map[string]bool)
+	for _, name := range names {
+		langExts, ok := languageExtensions[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("languages: unknown language %q (supported: %s)", name, strings.Join(languageNames(), ", "))
+		}
+		for _, ext := range langExts {
+			exts[ext] = true
+		}
+	}
+	return exts, nil
+}
+
+// languageNames returns the supported language names, sorted.
+func languageNames() []string {
+	names := make([]string, 0, len(languageExtensions))
+	for name := range languageExtensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
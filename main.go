@@ -1,14 +1,16 @@
 package main
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
 	"github.com/nox-hq/nox/sdk"
@@ -23,48 +25,220 @@ var (
 	reGoHTTPHandle = regexp.MustCompile(`(?:http\.HandleFunc|http\.Handle|mux\.HandleFunc|mux\.Handle|r\.HandleFunc|r\.Handle)\s*\(\s*["']([^"']+)["']`)
 	reGoGinRoute   = regexp.MustCompile(`(?:r|router|g|group|e|engine)\.\s*(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS|Any)\s*\(\s*["']([^"']+)["']`)
 	reGoEchoRoute  = regexp.MustCompile(`(?:e|echo)\.\s*(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS)\s*\(\s*["']([^"']+)["']`)
-	reGoChiRoute   = regexp.MustCompile(`(?:r|router)\.\s*(Get|Post|Put|Delete|Patch|Head|Options|Route)\s*\(\s*["']([^"']+)["']`)
+	reGoChiRoute   = regexp.MustCompile(`(?:r|router)\.\s*(Get|Post|Put|Delete|Patch|Head|Options|Route|Mount)\s*\(\s*["']([^"']+)["']`)
 
 	// Python HTTP endpoints.
-	rePyFlask   = regexp.MustCompile(`@(?:app|blueprint|bp)\.\s*(?:route|get|post|put|delete|patch)\s*\(\s*["']([^"']+)["']`)
-	rePyDjango  = regexp.MustCompile(`(?:path|re_path|url)\s*\(\s*["']([^"']+)["']`)
-	rePyFastAPI = regexp.MustCompile(`@(?:app|router)\.\s*(?:get|post|put|delete|patch|head|options)\s*\(\s*["']([^"']+)["']`)
+	rePyFlask   = regexp.MustCompile(`@(?:app|blueprint|bp)\.\s*(route|get|post|put|delete|patch)\s*\(\s*["']([^"']+)["']`)
+	rePyDjango  = regexp.MustCompile(`(?:path|re_path|url)\s*\(\s*r?["']([^"']+)["']`)
+	rePyFastAPI = regexp.MustCompile(`@(?:app|router)\.\s*(get|post|put|delete|patch|head|options)\s*\(\s*["']([^"']+)["']`)
 
 	// JavaScript/TypeScript HTTP endpoints.
 	reJSExpress = regexp.MustCompile(`(?:app|router)\.\s*(get|post|put|delete|patch|all|use)\s*\(\s*['"]([^'"]+)['"]`)
 	reJSKoa     = regexp.MustCompile(`(?:router)\.\s*(get|post|put|delete|patch|all)\s*\(\s*['"]([^'"]+)['"]`)
 	reJSFastify = regexp.MustCompile(`(?:fastify|server|app)\.\s*(get|post|put|delete|patch|all|route)\s*\(\s*['"]([^'"]+)['"]`)
 
+	// Kotlin HTTP endpoints (Ktor routing DSL, Micronaut/Spring annotations).
+	reKtorRoute      = regexp.MustCompile(`(?:^|[^.\w])(get|post|put|delete|patch|head|options)\s*\(\s*"([^"]*)"\s*\)\s*\{`)
+	reKtorRouteBlock = regexp.MustCompile(`(?:^|[^.\w])route\s*\(\s*"([^"]+)"\s*\)\s*\{`)
+	reKtAnnotation   = regexp.MustCompile(`@(Get|Post|Put|Delete|Patch|Head|Options|GetMapping|PostMapping|PutMapping|DeleteMapping|PatchMapping|RequestMapping)\s*\(\s*(?:value\s*=\s*|uri\s*=\s*)?"([^"]+)"`)
+
+	// C# HTTP endpoints (ASP.NET Core attribute routing and minimal APIs).
+	reCsHTTPAttribute  = regexp.MustCompile(`\[\s*Http(Get|Post|Put|Delete|Patch|Head|Options)\s*(?:\(\s*"([^"]*)"[^)]*\))?\s*[\],]`)
+	reCsRouteAttribute = regexp.MustCompile(`\[\s*Route\s*\(\s*"([^"]*)"\s*\)\s*\]`)
+	reCsMinimalAPI     = regexp.MustCompile(`\.Map(Get|Post|Put|Delete|Patch|Methods|Group)?\s*\(\s*"([^"]*)"`)
+	reCsClass          = regexp.MustCompile(`\bclass\s+(\w+)`)
+
 	// Auth middleware patterns.
-	reAuthMiddleware = regexp.MustCompile(`(?i)(auth.?middleware|requireAuth|isAuthenticated|authenticate|jwt.?middleware|passport\.|@login_required|@requires_auth|AuthGuard|UseGuards|Depends\(.*auth)`)
+	reAuthMiddleware = regexp.MustCompile(`(?i)(auth.?middleware|requireAuth|isAuthenticated|authenticate|jwt.?middleware|passport\.|@login_required|@requires_auth|AuthGuard|UseGuards|Depends\(.*auth|\[Authorize\b|RequireAuthorization\()`)
+
+	// Auth hints that fall short of recognized middleware (tokens, sessions,
+	// framework role annotations).
+	reAuthHint = regexp.MustCompile(`(?i)\b(?:token|session|jwt|bearer|authorization|api[_-]?key|current_?user|@Secured|@PreAuthorize|@RolesAllowed)\b`)
+
+	// Endpoint paths that suggest privileged or personal data.
+	reSensitivePath = regexp.MustCompile(`(?i)/(?:admin|users?|accounts?|billing|payments?|orders?|profile|settings|internal|export|keys|tokens?|secrets?|credentials)\b`)
 
 	// Admin/debug endpoints.
 	reAdminDebug = regexp.MustCompile(`(?i)(/admin|/debug|/metrics|/health|/status|/internal|/actuator|/__debug__|/pprof|/swagger|/graphql|/playground)`)
 
 	// File upload handling.
-	reFileUpload = regexp.MustCompile(`(?i)(multipart|FormFile|upload|multer|FileField|UploadFile|busboy|formidable)`)
+	reFileUpload = regexp.MustCompile(`(?i)(multipart|FormFile|upload|multer|FileField|UploadFile|busboy|formidable|request\.files\b)`)
 
 	// WebSocket endpoints.
 	reWebSocket = regexp.MustCompile(`(?i)(websocket|ws://|wss://|Upgrader|socket\.io|@WebSocket|@SubscribeMessage|\.ws\(|\.websocket\()`)
+
+	// GraphQL query-cost protections.
+	reGraphQLDepthLimit    = regexp.MustCompile(`(?i)(?:depthLimit|depth_limit|maxDepth|max_depth)`)
+	reGraphQLCostLimit     = regexp.MustCompile(`(?i)(?:costAnalysis|cost_analysis|complexityLimit|queryComplexity|max_complexity|maxComplexity)`)
+	reGraphQLBatchDisabled = regexp.MustCompile(`(?i)(?:allowBatchedHttpRequests\s*:\s*false|allowBatchedQueries\s*:\s*false|batching\s*:\s*false|batch\s*=\s*False)`)
+
+	// SSRF: request input interpolated into outbound URLs. A host-controlled
+	// URL has the interpolation immediately after the scheme; a
+	// path-controlled URL has a fixed host followed by interpolation.
+	reSSRFHostInterp = regexp.MustCompile("https?://(?:[\"'`]\\s*\\+|\\$\\{|\\{|%[sv])")
+	reSSRFPathInterp = regexp.MustCompile("https?://[^\"'`\\s/{}$%]+/[^\"'`\\s]*(?:[\"'`]\\s*\\+|\\$\\{|\\{|%[sv])")
+	reRequestInput   = regexp.MustCompile(`(?:req|request)\.(?:query|params|body|args|GET|POST|form|json|values)|r\.URL\.Query|\bc\.(?:Query|Param|PostForm)|FormValue|getParameter|@RequestParam|ctx\.(?:query|params)`)
+	reOutboundHTTP   = regexp.MustCompile(`\b(?:fetch|axios(?:\.\w+)?|http\.(?:Get|Post|NewRequest\w*)|requests\.(?:get|post|put|delete|request)|urllib\.request\.urlopen|httpx\.\w+|got|superagent\.\w+)\s*\(`)
+
+	// Health-style routes and internals they should not disclose.
+	reHealthRoute   = regexp.MustCompile(`(?i)/(?:health|healthz|healthcheck|status|readyz?|livez?|ping)/?$`)
+	reHealthDetail  = regexp.MustCompile(`(?i)\b(?:version|hostname|os\.Hostname|runtime\.Version|process\.(?:version|env|memoryUsage)|platform\.\w+|uptime|database|db_?(?:host|status|url)|redis|postgres|mysql|mongo|dependencies|build_?info|commit)\b`)
+	reNamedHandler  = regexp.MustCompile(`,\s*(?:\w+\.)*(\w+)\s*\)\s*;?\s*$`)
+	reIndentedBlock = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s`)
+	reHandlerDef    = regexp.MustCompile(`(?:func|def|function)\s+(?:\([^)]*\)\s*)?(\w+)\s*\(`)
+
+	// Webhook receivers and signature verification.
+	reWebhookRoute     = regexp.MustCompile(`(?i)(?:^|/)[\w-]*(?:webhooks?|hooks)(?:/|$)`)
+	reWebhookSignature = regexp.MustCompile(`(?i)(X-Hub-Signature(?:-256)?|Stripe-Signature|X-Slack-Signature|X-Twilio-Signature|X-Shopify-Hmac-Sha256|X-Gitlab-Token|Svix-Signature|X-Signature)`)
+	reWebhookVerify    = regexp.MustCompile(`(?i)(?:verify_?webhook|verify_?signature|verifyRequestSignature|constructEvent|construct_event|compare_digest|timingSafeEqual|ConstantTimeCompare|hmac\.(?:Equal|new|New)|createHmac|validate_?request|webhooks\.verify)`)
+
+	// Neutralization of spreadsheet formula prefixes (=, +, -, @).
+	reFormulaSanitizer = regexp.MustCompile(`(?i)(?:sanitiz|escape_?formula|escape_?csv|neutrali[sz]e|\[=\+\\?-@\]|["']=\+-@["']|'=',\s*'\+')`)
+
+	// NoSQL queries built from request input.
+	reMongoQuery        = regexp.MustCompile(`\.(?:find|findOne|findOneAndUpdate|findOneAndDelete|updateOne|updateMany|deleteOne|deleteMany|aggregate|countDocuments|find_one|update_one|update_many|delete_one|delete_many|count_documents|FindOne|Find|UpdateOne|DeleteOne)\s*\(`)
+	reMongoWhere        = regexp.MustCompile(`['"]?\$where['"]?\s*:\s*[^'"\s]`)
+	reSpreadRequestBody = regexp.MustCompile(`\.\.\.\s*(?:req|request|ctx\.request)\.(?:body|query|params)`)
+
+	// Fast, unsalted digests applied to passwords. Key-derivation functions
+	// on the same line mean the digest is not the password hash itself.
+	reWeakHashCall = regexp.MustCompile(`(?i)(?:hashlib\.(md5|sha1|sha224|sha256|sha384|sha512)\s*\(|createHash\s*\(\s*["'](md5|sha1|sha256|sha512)["']|MessageDigest\.getInstance\s*\(\s*["'](md5|sha-?1|sha-?256|sha-?512)["']|\b(sha256|sha512)\.(?:Sum\d*|New)\s*\(|\b(md5|sha1)(?:\.Sum|\.New|_hex|Hex)?\s*\()`)
+	rePasswordVar  = regexp.MustCompile(`(?i)(?:passw(?:or)?d|\bpwd\b|\bpw\b|passphrase)`)
+	reStrongKDF    = regexp.MustCompile(`(?i)(?:bcrypt|argon2|scrypt|pbkdf2)`)
+
+	// LDAP filters built by interpolation, e.g. "(uid=" + user + ")", and
+	// the escaping helpers that make them safe.
+	reLDAPFilterInterp = regexp.MustCompile("\\(\\w+=(?:[\"'`]\\s*\\+|\\$?\\{|%[sv])")
+	reLDAPEscape       = regexp.MustCompile(`(?i)(?:escape_filter_chars|filter_format|EscapeFilter|escapeLDAP|ldap\.filter\.escape|escape_rdn)`)
+
+	// Go types implementing http.Handler directly.
+	reGoServeHTTP = regexp.MustCompile(`func\s*\(\s*\w*\s*\*?\s*(\w+)\s*\)\s*ServeHTTP\s*\(`)
+
+	// Logging calls and the sensitive values they may leak.
+	reLogCall         = regexp.MustCompile(`(?i)\b(?:log|logger|logging|console|slog|logrus|zap)\.\w+\s*\((.*)`)
+	reLogWholeRequest = regexp.MustCompile(`\b(?:r|req|request)\.(?:Header|headers|Body|body|data|form|Form|cookies|Cookies)(?:[^.\[\w]|$)`)
+	reLogSecretField  = regexp.MustCompile(`(?i)\b(?:password|passwd|secret|token|api_?key|authorization|cookie|credentials?)\b`)
+	reLogSanitized    = regexp.MustCompile(`(?i)(?:redact|sanitiz|mask|scrub)`)
+	// The {expr}/${expr} interpolations inside string literals in log
+	// arguments, so message text does not count as a logged field.
+	reLogInterpolation = regexp.MustCompile(`\{([^{}]*)\}`)
+
+	// Double-, single-, and backtick-quoted string literals, with escapes.
+	reQuotedString = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`")
 )
 
+// graphqlServers identifies GraphQL server setups by library.
+var graphqlServers = []struct {
+	library string
+	re      *regexp.Regexp
+}{
+	{"apollo-server", regexp.MustCompile(`new\s+ApolloServer\s*\(`)},
+	{"express-graphql", regexp.MustCompile(`\bgraphqlHTTP\s*\(`)},
+	{"graphql-yoga", regexp.MustCompile(`\bcreateYoga\s*\(`)},
+	{"mercurius", regexp.MustCompile(`\.register\s*\(\s*mercurius\b`)},
+	{"graphene", regexp.MustCompile(`\bGraphQLView\.as_view\s*\(`)},
+	{"strawberry", regexp.MustCompile(`\bGraphQLRouter\s*\(`)},
+	{"gqlgen", regexp.MustCompile(`\bhandler\.(?:NewDefaultServer|New)\s*\(`)},
+}
+
+// spreadsheetWriters identifies CSV/spreadsheet generation by library.
+var spreadsheetWriters = []struct {
+	library string
+	re      *regexp.Regexp
+}{
+	{"encoding/csv", regexp.MustCompile(`\bcsv\.NewWriter\s*\(`)},
+	{"excelize", regexp.MustCompile(`\bexcelize\.NewFile\s*\(`)},
+	{"csv", regexp.MustCompile(`\bcsv\.(?:writer|DictWriter)\s*\(`)},
+	{"pandas", regexp.MustCompile(`\.to_(?:csv|excel)\s*\(`)},
+	{"openpyxl", regexp.MustCompile(`\bopenpyxl\b`)},
+	{"xlsxwriter", regexp.MustCompile(`\bxlsxwriter\b`)},
+	{"exceljs", regexp.MustCompile(`(?i)\bexceljs\b`)},
+	{"json2csv", regexp.MustCompile(`\bjson2csv\b`)},
+	{"csv-stringify", regexp.MustCompile(`\bcsv-stringify\b`)},
+	{"sheetjs", regexp.MustCompile(`\bXLSX\.(?:utils|write)`)},
+}
+
+// mongoDrivers identifies the MongoDB driver or ODM used by a file.
+var mongoDrivers = []struct {
+	driver string
+	re     *regexp.Regexp
+}{
+	{"mongoose", regexp.MustCompile(`\bmongoose\b`)},
+	{"mongoengine", regexp.MustCompile(`\bmongoengine\b`)},
+	{"motor", regexp.MustCompile(`\bmotor\.motor_asyncio\b`)},
+	{"pymongo", regexp.MustCompile(`\bpymongo\b`)},
+	{"mongo-go-driver", regexp.MustCompile(`go\.mongodb\.org/mongo-driver`)},
+	{"mongodb", regexp.MustCompile(`\bMongoClient\b|['"]mongodb['"]`)},
+}
+
+// ldapLibraries identifies LDAP client libraries by import or usage.
+var ldapLibraries = []struct {
+	library string
+	re      *regexp.Regexp
+}{
+	{"ldap3", regexp.MustCompile(`\bldap3\b`)},
+	{"python-ldap", regexp.MustCompile(`^\s*import\s+ldap\b|\bldap\.initialize\s*\(`)},
+	{"ldapjs", regexp.MustCompile(`['"]ldapjs['"]`)},
+	{"ldapts", regexp.MustCompile(`['"]ldapts['"]`)},
+	{"go-ldap", regexp.MustCompile(`github\.com/go-ldap/ldap`)},
+	{"spring-ldap", regexp.MustCompile(`\bLdapTemplate\b|org\.springframework\.ldap`)},
+}
+
+// bindAllPatterns match listen/bind calls on every interface. Each pattern
+// captures the bind address.
+var bindAllPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:ListenAndServe(?:TLS)?|\.Run(?:TLS)?|\.Start(?:TLS)?)\s*\(\s*"((?:0\.0\.0\.0|\[::\])?:\d+)"`),
+	regexp.MustCompile(`net\.Listen\s*\(\s*"tcp[46]?"\s*,\s*"((?:0\.0\.0\.0|\[::\])?:\d+)"`),
+	regexp.MustCompile(`\bAddr:\s*"((?:0\.0\.0\.0|\[::\])?:\d+)"`),
+	regexp.MustCompile(`\.listen\s*\([^)]*['"](0\.0\.0\.0|::)['"]`),
+	regexp.MustCompile(`(?i)\bhost\s*[:=]\s*['"](0\.0\.0\.0|::)['"]`),
+	regexp.MustCompile(`--host[= ](0\.0\.0\.0|::)\b`),
+}
+
 // sourceExtensions lists file extensions to scan.
 var sourceExtensions = map[string]bool{
-	".go":  true,
-	".py":  true,
-	".js":  true,
-	".ts":  true,
-	".jsx": true,
-	".tsx": true,
+	".go":   true,
+	".py":   true,
+	".js":   true,
+	".ts":   true,
+	".jsx":  true,
+	".tsx":  true,
+	".kt":   true,
+	".cs":   true,
+	".rb":   true,
+	".java": true,
+}
+
+// routeKeywords lists, per extension, literals of which at least one
+// appears in every match of that language's route patterns.
+var routeKeywords = map[string][]string{
+	".go": {
+		"Handle",
+		"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "Any",
+		"Get", "Post", "Put", "Delete", "Patch", "Head", "Options", "Route", "Mount",
+	},
+	".py":   {"@", "path", "url", "Handler"},
+	".js":   jsRouteKeywords,
+	".ts":   jsRouteKeywords,
+	".jsx":  jsRouteKeywords,
+	".tsx":  jsRouteKeywords,
+	".kt":   {"@", "get", "post", "put", "delete", "patch", "head", "options"},
+	".cs":   {"[", "Map"},
+	".java": {"Mapping"},
+	".rb":   {"get", "post", "put", "patch", "delete", "match", "resource", "mount", "root"},
 }
 
-// skippedDirs to skip during walks.
+var jsRouteKeywords = []string{"get", "post", "put", "delete", "patch", "all", "use", "route"}
+
+// jsExtensions lists the JavaScript and TypeScript source extensions.
+var jsExtensions = map[string]bool{".js": true, ".ts": true, ".jsx": true, ".tsx": true}
+
+// skippedDirs to skip during walks unless carved out with include_dirs.
+// Hidden tool directories are listed in hiddenSkippedDirs; see dirFilter.
 var skippedDirs = map[string]bool{
-	".git":         true,
 	"vendor":       true,
 	"node_modules": true,
 	"__pycache__":  true,
-	".venv":        true,
 	"dist":         true,
 	"build":        true,
 }
@@ -73,15 +247,25 @@ func buildServer() *sdk.PluginServer {
 	manifest := sdk.NewManifest("nox/attack-surface", version).
 		Capability("attack-surface", "Static endpoint extraction and attack surface inventory").
 		Tool("scan", "Extract HTTP endpoints, detect unauthenticated routes, admin/debug exposure, file uploads, and WebSocket endpoints", true).
+		Tool("rules", "List every rule the scanner can emit with its description, default severity, confidence, and category", true).
+		Tool("metadata_schema", "Write a JSON Schema describing the metadata keys each rule emits", true).
 		Done().
 		Safety(sdk.WithRiskClass(sdk.RiskPassive)).
 		Build()
 
 	return sdk.NewPluginServer(manifest).
-		HandleTool("scan", handleScan)
+		HandleTool("scan", handleScan).
+		HandleTool("rules", handleRules).
+		HandleTool("metadata_schema", handleMetadataSchema)
 }
 
 func handleScan(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolResponse, error) {
+	input, err := expandPathInputs(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	req.Input = input
+
 	workspaceRoot, _ := req.Input["workspace_root"].(string)
 	if workspaceRoot == "" {
 		workspaceRoot = req.WorkspaceRoot
@@ -93,173 +277,1775 @@ func handleScan(ctx context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolR
 		return resp.Build(), nil
 	}
 
-	err := filepath.WalkDir(workspaceRoot, func(path string, d os.DirEntry, err error) error {
+	configPath, config, configErrs := loadWorkspaceConfig(workspaceRoot)
+	toolInputs := req.Input
+	req.Input = mergeConfig(req.Input, config)
+
+	serviceDepth, _ := req.Input["service_root_depth"].(float64)
+	opts := &scanOptions{
+		errs:    &errorCollector{},
+		routes:  newRouteRegistry(workspaceRoot, int(serviceDepth)),
+		apiURLs: newAPIURLInventory(),
+		csp:     &cspTracker{},
+		served:  newUploadServingTracker(),
+		methods: newMethodOverrideTracker(workspaceRoot, int(serviceDepth)),
+		reach:   newReachabilityTracker(),
+	}
+	for _, err := range configErrs {
+		opts.errs.add(errKindConfig, configPath, err)
+	}
+	perFileTimeout, err := parseTimeout(req.Input["per_file_timeout"], "per_file_timeout")
+	if err != nil {
+		return nil, err
+	}
+	scanTimeout, err := parseTimeout(req.Input["scan_timeout"], "scan_timeout")
+	if err != nil {
+		return nil, err
+	}
+	if scanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, scanTimeout)
+		defer cancel()
+	}
+	dirs, err := parseDirFilter(req.Input)
+	if err != nil {
+		return nil, err
+	}
+	opts.dirs = dirs
+	opts.publicEndpoints, err = parsePublicEndpoints(req.Input["public_endpoints"])
+	if err != nil {
+		return nil, err
+	}
+	opts.languages, err = parseLanguages(req.Input["languages"])
+	if err != nil {
+		return nil, err
+	}
+	opts.scanDockerfiles, _ = req.Input["scan_dockerfiles"].(bool)
+	opts.scanTemplates, _ = req.Input["scan_templates"].(bool)
+	opts.scanTerraform, _ = req.Input["scan_terraform"].(bool)
+	subs, err := loadSubmodules(workspaceRoot)
+	opts.errs.add(errKindRead, filepath.Join(workspaceRoot, ".gitmodules"), err)
+	opts.skipSubmodules, _ = req.Input["skip_submodules"].(bool)
+	opts.submodules = subs
+	if resolve, _ := req.Input["resolve_proxy_paths"].(bool); resolve {
+		opts.rewrites = collectProxyRewrites(ctx, workspaceRoot, opts.dirs, opts.errs)
+	}
+	if baselinePath, _ := req.Input["baseline_path"].(string); baselinePath != "" {
+		baseline, err := loadBaseline(baselinePath)
+		if err != nil {
+			return nil, fmt.Errorf("loading baseline: %w", err)
+		}
+		opts.baseline = baseline
+	}
+	var suppressions *suppressionSet
+	if path, _ := req.Input["suppressions"].(string); path != "" {
+		set, err := loadSuppressions(path, workspaceRoot)
+		if err != nil {
+			return nil, fmt.Errorf("loading suppressions: %w", err)
+		}
+		suppressions = set
+	}
+	riskScores, err := parseRiskScores(req.Input["risk_scores"])
+	if err != nil {
+		return nil, err
+	}
+	fileScores, err := parseFileScores(req.Input["file_scores"])
+	if err != nil {
+		return nil, err
+	}
+	opts.sensitivity, err = parseSensitivityKeywords(req.Input["sensitivity_keywords"])
+	if err != nil {
+		return nil, err
+	}
+	idPrefix, err := parseIDPrefix(req.Input["id_prefix"])
+	if err != nil {
+		return nil, err
+	}
+	topN, err := parseTopN(req.Input["top_n"])
+	if err != nil {
+		return nil, err
+	}
+	minConfidence, err := parseMinConfidence(req.Input["min_confidence"])
+	if err != nil {
+		return nil, err
+	}
+	categories, err := parseCategoryFilter(req.Input["include_categories"], req.Input["exclude_categories"])
+	if err != nil {
+		return nil, err
+	}
+	var manifest *manifestRecorder
+	manifestPath, _ := req.Input["manifest_output"].(string)
+	if manifestPath != "" {
+		manifest = newManifestRecorder(workspaceRoot, configPath, toolInputs, req.Input, categories)
+	}
+	diffRange, err := parseGitDiff(req.Input["git_diff"])
+	if err != nil {
+		return nil, err
+	}
+	if diffRange != nil {
+		changed, err := diffRange.changedFiles(ctx, workspaceRoot)
+		if err != nil {
+			// Not a git repo or unknown refs: scan everything instead.
+			opts.errs.add(errKindGit, workspaceRoot, err)
+		} else {
+			opts.onlyFiles = changed
+		}
+	}
+	hunks, hunksFromGit, err := parseDiffHunks(req.Input["diff_hunks"], diffRange)
+	if err != nil {
+		return nil, err
+	}
+	if hunksFromGit && opts.onlyFiles != nil {
+		hunks, err = diffRange.changedHunks(ctx, workspaceRoot)
+		if err != nil {
+			// Keep the file-level selection and report every finding in it.
+			opts.errs.add(errKindGit, workspaceRoot, err)
+		}
+	}
+	if hunks != nil && opts.onlyFiles == nil {
+		opts.onlyFiles = hunks.files(workspaceRoot)
+	}
+	inventoryPath, _ := req.Input["inventory_output"].(string)
+	if inventoryPath != "" {
+		opts.inventory = &inventoryRecorder{root: workspaceRoot}
+	}
+	if report, _ := req.Input["coverage_report"].(bool); report {
+		opts.coverage = &coverageTracker{}
+	}
+	if untested, _ := req.Input["untested_endpoints"].(bool); untested {
+		opts.tests = newTestReferenceTracker()
+	}
+
+	var progress *progressReporter
+	if progressPath, _ := req.Input["progress_output"].(string); progressPath != "" {
+		interval, err := parseTimeout(req.Input["progress_interval"], "progress_interval")
+		if err != nil {
+			return nil, err
+		}
+		progress, err = newProgressReporter(progressPath, interval, countWalkFiles(ctx, workspaceRoot, opts))
+		if err != nil {
+			return nil, fmt.Errorf("writing progress: %w", err)
+		}
+	}
+
+	// filterFindings is the per-finding post-processing shared by the
+	// response and the batches streamed to webhook_url.
+	fingerprints := newFingerprinter(workspaceRoot)
+	absolute, _ := req.Input["absolute_paths"].(bool)
+	filterFindings := func(out *pluginv1.InvokeToolResponse) {
+		opts.submodules.tag(out, workspaceRoot)
+		if !absolute {
+			relativizeFindings(out, workspaceRoot)
+		}
+		if suppressions != nil {
+			suppressions.apply(out)
+		}
+		if hunks != nil {
+			hunks.apply(out, workspaceRoot)
+		}
+		if minConfidence != 0 {
+			applyMinConfidence(out, minConfidence)
+		}
+		if categories != nil {
+			categories.apply(out)
+		}
+	}
+
+	// Webhook delivery outlives scan_timeout so partial results still
+	// arrive; each POST has its own timeout.
+	webhookCtx := context.WithoutCancel(ctx)
+	var webhook *webhookStreamer
+	if webhookURL, _ := req.Input["webhook_url"].(string); webhookURL != "" {
+		webhook, err = newWebhookStreamer(webhookCtx, webhookURL, func(batch *pluginv1.InvokeToolResponse) {
+			fingerprints.tag(batch)
+			filterFindings(batch)
+			tagCategories(batch)
+			if idPrefix != "" {
+				applyIDPrefix(batch, idPrefix)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer webhook.close()
+	}
+
+	// Candidate files are collected first and scanned in parallel.
+	var files []string
+	err = filepath.WalkDir(workspaceRoot, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
+			opts.errs.add(errKindWalk, path, err)
 			return nil
 		}
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 		if d.IsDir() {
-			if skippedDirs[d.Name()] {
+			if opts.skipDir(workspaceRoot, path, d.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
-		ext := filepath.Ext(path)
-		if !sourceExtensions[ext] {
+		if opts.onlyFiles != nil && !opts.onlyFiles[path] {
 			return nil
 		}
-
-		return scanFileForEndpoints(resp, path, ext)
+		files = append(files, path)
+		return nil
 	})
-	if err != nil && err != context.Canceled {
+	if err == nil {
+		err = scanFiles(ctx, files, opts, func(ctx context.Context, fileResp *sdk.ResponseBuilder, path string, shard *scanOptions) error {
+			return scanPath(ctx, fileResp, path, shard, perFileTimeout)
+		}, func(fileResp *sdk.ResponseBuilder) {
+			findings := fileResp.Build().GetFindings()
+			addFindings(resp, findings)
+			if progress != nil {
+				progress.tick()
+			}
+			if webhook != nil {
+				webhook.collect(findings)
+			}
+		})
+	}
+	if progress != nil {
+		if perr := progress.finish(); perr != nil {
+			return nil, fmt.Errorf("writing progress: %w", perr)
+		}
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		opts.errs.add(errKindTimeout, workspaceRoot, fmt.Errorf("scan stopped after scan_timeout of %s; results are partial: %w", scanTimeout, err))
+	case err != nil && err != context.Canceled:
 		return nil, fmt.Errorf("walking workspace: %w", err)
 	}
 
-	return resp.Build(), nil
-}
+	if opts.baseline != nil {
+		for _, e := range opts.baseline.removed() {
+			file := e.File
+			if file != "" && !filepath.IsAbs(file) {
+				file = filepath.Join(workspaceRoot, file)
+			}
+			if opts.onlyFiles != nil && !opts.onlyFiles[file] {
+				// Not rescanned, so absence proves nothing.
+				continue
+			}
+			f := newFinding(
+				resp,
+				"ATTACK-001",
+				fmt.Sprintf("HTTP endpoint removed: %s", e.Endpoint),
+			).
+				At(file, e.Line, e.Line).
+				WithMetadata("endpoint", e.Endpoint).
+				WithMetadata("drift", "removed")
+			if e.Framework != "" {
+				f.WithMetadata("framework", e.Framework)
+			}
+			withSensitivity(f, opts.sensitivity, e.Endpoint, "")
+			f.Done()
+		}
+	}
 
-// scanFileForEndpoints extracts endpoints and checks for attack surface issues.
-func scanFileForEndpoints(resp *sdk.ResponseBuilder, filePath, ext string) error {
-	f, err := os.Open(filePath)
-	if err != nil {
-		return nil
+	if opts.inventory != nil {
+		if err := opts.inventory.write(inventoryPath); err != nil {
+			return nil, fmt.Errorf("writing inventory: %w", err)
+		}
 	}
-	defer func() { _ = f.Close() }()
 
-	scanner := bufio.NewScanner(f)
-	lineNum := 0
+	opts.routes.reportDuplicates(resp)
+	opts.apiURLs.report(resp)
+	opts.csp.report(resp)
+	opts.served.report(resp)
+	opts.methods.report(resp)
+	if opts.tests != nil {
+		opts.tests.report(resp)
+	}
+	correlateRisk(resp)
+	if fileScores != nil {
+		scoreFiles(resp, fileScores)
+	}
+	opts.errs.report(resp)
+	if opts.coverage != nil {
+		opts.coverage.report(resp, workspaceRoot)
+	}
 
-	// Track auth middleware per file.
-	hasAuthInFile := false
-	var lines []string
+	if report, _ := req.Input["report_suppressed"].(bool); report && suppressions != nil {
+		if n := suppressions.count(resp.Build().GetFindings()); n > 0 {
+			newFinding(
+				resp,
+				"ATTACK-000",
+				fmt.Sprintf("Suppressed %d accepted finding(s)", n),
+			).
+				At(suppressions.path, 0, 0).
+				WithMetadata("suppressed", strconv.Itoa(n)).
+				Done()
+		}
+	}
 
-	// First pass: read all lines and check for auth middleware.
-	for scanner.Scan() {
-		line := scanner.Text()
-		lines = append(lines, line)
-		if reAuthMiddleware.MatchString(line) {
-			hasAuthInFile = true
+	out := resp.Build()
+	fingerprints.tag(out)
+	opts.reach.tag(out)
+	filterFindings(out)
+	if aggregate, _ := req.Input["aggregate_by_endpoint"].(bool); aggregate {
+		aggregateByEndpoint(out)
+	}
+	tagCategories(out)
+	if riskScores != nil {
+		applyRiskScores(out, riskScores)
+	}
+	if topN > 0 {
+		notePath := workspaceRoot
+		if !absolute {
+			notePath = relativePath(workspaceRoot, workspaceRoot)
 		}
+		note := &pluginv1.InvokeToolResponse{Findings: []*pluginv1.Finding{topNNote(topN, applyTopN(out, topN), notePath)}}
+		fingerprints.tag(note)
+		tagCategories(note)
+		out.Findings = append(out.Findings, note.GetFindings()...)
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+	if idPrefix != "" {
+		applyIDPrefix(out, idPrefix)
+	}
+	if webhook != nil {
+		if errs := webhook.finish(out); len(errs) > 0 {
+			out.Findings = append(out.Findings, webhookDiagnostics(webhook, errs, fingerprints, idPrefix)...)
+		}
 	}
+	sortFindings(out)
+	if csvPath, _ := req.Input["csv_output"].(string); csvPath != "" {
+		if err := writeFindingsCSV(csvPath, workspaceRoot, out.GetFindings()); err != nil {
+			return nil, fmt.Errorf("writing CSV: %w", err)
+		}
+	}
+	if manifest != nil {
+		if err := manifest.write(manifestPath, len(out.GetFindings())); err != nil {
+			return nil, fmt.Errorf("writing manifest: %w", err)
+		}
+	}
+	return out, nil
+}
 
-	// Second pass: find endpoints.
-	for i, line := range lines {
-		lineNum = i + 1
+// parseTimeout reads an optional duration input such as "30s" or "2m".
+// Zero means no limit.
+func parseTimeout(v any, input string) (time.Duration, error) {
+	if v == nil {
+		return 0, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("%s must be a duration string such as \"30s\", got %T", input, v)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration such as \"30s\", got %q", input, s)
+	}
+	return d, nil
+}
 
-		endpoint := extractEndpoint(line, ext)
-		if endpoint != "" {
-			// ATTACK-001: HTTP endpoint detected.
-			resp.Finding(
-				"ATTACK-001",
-				sdk.SeverityInfo,
-				sdk.ConfidenceHigh,
-				fmt.Sprintf("HTTP endpoint detected: %s", endpoint),
-			).
-				At(filePath, lineNum, lineNum).
-				WithMetadata("endpoint", endpoint).
-				Done()
+// scanOptions carries per-invocation settings and state shared by every
+// file scanned in a single tool call.
+type scanOptions struct {
+	// rewrites maps internal endpoints to externally exposed paths.
+	rewrites proxyRewrites
+	// baseline, when set, limits endpoint findings to newly added routes.
+	baseline *endpointBaseline
+	// inventory, when set, records every endpoint for export.
+	inventory *inventoryRecorder
+	// scanDockerfiles enables Dockerfile port/entrypoint analysis.
+	scanDockerfiles bool
+	// scanTemplates enables form and inline handler analysis of HTML
+	// templates.
+	scanTemplates bool
+	// scanTerraform enables exposure analysis of Terraform files.
+	scanTerraform bool
+	// errs collects non-fatal errors reported as ATTACK-000 diagnostics.
+	errs *errorCollector
+	// onlyFiles, when non-nil, restricts the scan to these absolute paths.
+	onlyFiles map[string]bool
+	// routes collects registrations for duplicate-route detection.
+	routes *routeRegistry
+	// dirs selects which directories are walked.
+	dirs dirFilter
+	// coverage, when set, records framework imports and endpoint counts.
+	coverage *coverageTracker
+	// apiURLs groups hardcoded API URLs by host across files.
+	apiURLs *apiURLInventory
+	// csp tracks CSP configuration and HTML rendering across files.
+	csp *cspTracker
+	// served pairs upload directories with static-serving registrations.
+	served *uploadServingTracker
+	// methods pairs HTTP method overrides with method-based controls.
+	methods *methodOverrideTracker
+	// publicEndpoints are extra patterns for endpoints not to flag as
+	// unauthenticated.
+	publicEndpoints publicEndpointPatterns
+	// submodules are declared in the workspace's .gitmodules.
+	submodules submodules
+	// skipSubmodules leaves submodule directories out of the walk.
+	skipSubmodules bool
+	// sensitivity tags endpoints by data sensitivity keywords.
+	sensitivity sensitivityKeywords
+	// tests, when set, pairs endpoints with test file references.
+	tests *testReferenceTracker
+	// languages, when non-nil, restricts source files to these
+	// extensions.
+	languages map[string]bool
+	// reach marks routes that may never be registered at runtime.
+	reach *reachabilityTracker
+}
 
-			// ATTACK-002: Check if endpoint lacks auth.
-			if !hasAuthInFile && !isCommonPublicEndpoint(endpoint) {
-				resp.Finding(
-					"ATTACK-002",
-					sdk.SeverityMedium,
-					sdk.ConfidenceMedium,
-					fmt.Sprintf("Potentially unauthenticated endpoint: %s", endpoint),
-				).
-					At(filePath, lineNum, lineNum).
-					WithMetadata("endpoint", endpoint).
-					Done()
-			}
+// skipDir reports whether the walk leaves out the directory at path.
+func (o *scanOptions) skipDir(root, path, name string) bool {
+	if path != root && o.dirs.skip(name) {
+		return true
+	}
+	return o.skipSubmodules && o.submodules.at(root, path) != ""
+}
 
-			// ATTACK-003: Admin/debug endpoint.
-			if reAdminDebug.MatchString(endpoint) {
-				resp.Finding(
-					"ATTACK-003",
-					sdk.SeverityMedium,
-					sdk.ConfidenceHigh,
-					fmt.Sprintf("Admin/debug endpoint exposed: %s", endpoint),
-				).
-					At(filePath, lineNum, lineNum).
-					WithMetadata("endpoint", endpoint).
-					Done()
-			}
-		}
+// scanPath scans the file at path with the scanner for its type. Read
+// errors and per-file timeouts are recorded in opts.errs; the returned
+// error is ctx's when the whole scan was cancelled or ran out of time.
+func scanPath(ctx context.Context, resp *sdk.ResponseBuilder, path string, opts *scanOptions, perFileTimeout time.Duration) error {
+	name := filepath.Base(path)
+	if isSpringConfig(name) {
+		opts.errs.add(errKindRead, path, scanSpringConfig(resp, path))
+	}
 
-		// ATTACK-004: File upload handling.
-		if reFileUpload.MatchString(line) {
-			resp.Finding(
-				"ATTACK-004",
-				sdk.SeverityLow,
-				sdk.ConfidenceMedium,
-				fmt.Sprintf("File upload handling detected: %s", strings.TrimSpace(line)),
-			).
-				At(filePath, lineNum, lineNum).
-				Done()
-		}
+	if opts.scanDockerfiles && isDockerfile(name) {
+		opts.errs.add(errKindRead, path, scanDockerfile(resp, path))
+		return nil
+	}
 
-		// ATTACK-005: WebSocket endpoint.
-		if reWebSocket.MatchString(line) {
-			resp.Finding(
-				"ATTACK-005",
-				sdk.SeverityMedium,
-				sdk.ConfidenceMedium,
-				fmt.Sprintf("WebSocket endpoint detected: %s", strings.TrimSpace(line)),
-			).
-				At(filePath, lineNum, lineNum).
-				Done()
-		}
+	// Terraform files are also checked for embedded credentials below.
+	if opts.scanTerraform && filepath.Ext(name) == ".tf" {
+		opts.errs.add(errKindRead, path, scanTerraform(resp, path))
+	}
+
+	if isCredentialConfig(name) {
+		opts.errs.add(errKindRead, path, scanCredentialConfig(resp, path))
+		return nil
+	}
+
+	ext := filepath.Ext(path)
+	if ext == ".graphql" || ext == ".gql" {
+		opts.errs.add(errKindRead, path, scanGraphQLSchema(resp, path))
+		return nil
+	}
+	if opts.scanTemplates && templateExtensions[ext] {
+		opts.errs.add(errKindRead, path, scanTemplate(resp, path, opts))
+		return nil
+	}
+	if pemExtensions[ext] {
+		opts.errs.add(errKindRead, path, scanPEMFile(resp, path))
+		return nil
+	}
+	if !sourceExtensions[ext] || (opts.languages != nil && !opts.languages[ext]) {
+		return nil
 	}
 
+	fileCtx := ctx
+	if perFileTimeout > 0 {
+		var cancel context.CancelFunc
+		fileCtx, cancel = context.WithTimeout(ctx, perFileTimeout)
+		defer cancel()
+	}
+	err := scanFileForEndpoints(fileCtx, resp, path, ext, opts)
+	if errors.Is(err, context.DeadlineExceeded) {
+		if ctx.Err() != nil {
+			// The whole scan ran out of time, not just this file.
+			return ctx.Err()
+		}
+		err = fmt.Errorf("exceeded per_file_timeout of %s: %w", perFileTimeout, err)
+	}
+	opts.errs.add(errKindRead, path, err)
 	return nil
 }
 
-// extractEndpoint tries to extract an HTTP endpoint path from a line.
-func extractEndpoint(line, ext string) string {
-	switch ext {
-	case ".go":
-		if m := reGoHTTPHandle.FindStringSubmatch(line); len(m) > 1 {
-			return m[1]
+// scanFileForEndpoints extracts endpoints and checks for attack surface issues.
+// A returned error means the file could not be read or ctx expired before it
+// was fully scanned; findings emitted before the failure are kept.
+func scanFileForEndpoints(ctx context.Context, resp *sdk.ResponseBuilder, filePath, ext string, opts *scanOptions) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	lineNum := 0
+
+	// Track auth middleware per file.
+	hasAuthInFile := false
+
+	// Track whether the file defines any routes.
+	hasEndpointInFile := false
+
+	// Track weaker auth hints that lower confidence in ATTACK-002.
+	hasAuthHintInFile := false
+
+	// Track outbound HTTP clients for SSRF checks.
+	hasOutboundCallInFile := false
+
+	// Track CSV/spreadsheet generation fed by request data.
+	spreadsheetLine, spreadsheetLibrary := 0, ""
+	hasRequestInputInFile, hasFormulaSanitizer := false, false
+
+	// Track Go request body reads and whether any are size-limited.
+	goBodyReadLine, hasMaxBytesReader := 0, false
+
+	// Track admin/debug surface, which raises the severity of ATTACK-059.
+	hasAdminSurfaceInFile := false
+
+	// Track the MongoDB driver/ODM for NoSQL injection findings.
+	mongoDriver := ""
+
+	// Track the LDAP client library for LDAP injection findings.
+	ldapLibrary := ""
+
+	// Track upload handling for upload destination findings.
+	hasUploadInFile := false
+
+	// Track math/rand imports for insecure randomness findings.
+	hasGoMathRand := false
+
+	// Track the first web framework import for the coverage report.
+	fileFramework, fileFrameworkLine := "", 0
+
+	// Track CSP configuration for unsafe directive findings.
+	hasCSPInFile := false
+
+	// Track GraphQL server setup and the protections configured for it.
+	graphqlLine, graphqlLibrary := 0, ""
+	hasDepthLimit, hasCostLimit, hasBatchDisabled := false, false, false
+
+	// Ruby route DSL calls register routes only in routes files; elsewhere,
+	// e.g. `get :show` in a controller spec, they are requests.
+	routesFile := ext != ".rb" || isRailsRoutesFile(filePath, lines)
+
+	// First pass: check for auth middleware and routes.
+	for i, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		if m := reGoGinRoute.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		if isAuthMiddleware(line) {
+			hasAuthInFile = true
 		}
-		if m := reGoEchoRoute.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		if reAuthHint.MatchString(line) {
+			hasAuthHintInFile = true
 		}
-		if m := reGoChiRoute.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		if endpoint := extractEndpoint(line, ext); endpoint != "" && routesFile {
+			hasEndpointInFile = true
+			if reAdminDebug.MatchString(endpoint) {
+				hasAdminSurfaceInFile = true
+			}
 		}
-	case ".py":
-		if m := rePyFlask.FindStringSubmatch(line); len(m) > 1 {
-			return m[1]
+		if strings.Contains(line, "net/http/pprof") {
+			hasAdminSurfaceInFile = true
 		}
-		if m := rePyDjango.FindStringSubmatch(line); len(m) > 1 {
-			return m[1]
+		if reOutboundHTTP.MatchString(line) {
+			hasOutboundCallInFile = true
 		}
-		if m := rePyFastAPI.FindStringSubmatch(line); len(m) > 1 {
-			return m[1]
+		if spreadsheetLibrary == "" {
+			for _, sw := range spreadsheetWriters {
+				if sw.re.MatchString(line) {
+					spreadsheetLine, spreadsheetLibrary = i+1, sw.library
+					break
+				}
+			}
 		}
-	case ".js", ".ts", ".jsx", ".tsx":
-		if m := reJSExpress.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		if mongoDriver == "" {
+			for _, md := range mongoDrivers {
+				if md.re.MatchString(line) {
+					mongoDriver = md.driver
+					break
+				}
+			}
 		}
-		if m := reJSKoa.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		hasUploadInFile = hasUploadInFile || reFileUpload.MatchString(line)
+		hasGoMathRand = hasGoMathRand || (ext == ".go" && reGoMathRandPkg.MatchString(line))
+		hasCSPInFile = hasCSPInFile || reCSPConfig.MatchString(line)
+		if opts.coverage != nil && fileFramework == "" {
+			if fileFramework = importedFramework(line); fileFramework != "" {
+				fileFrameworkLine = i + 1
+			}
 		}
-		if m := reJSFastify.FindStringSubmatch(line); len(m) > 2 {
-			return m[2]
+		if ldapLibrary == "" {
+			for _, ll := range ldapLibraries {
+				if ll.re.MatchString(line) {
+					ldapLibrary = ll.library
+					break
+				}
+			}
+		}
+		if ext == ".go" && goBodyReadLine == 0 && reGoBodyRead.MatchString(line) {
+			goBodyReadLine = i + 1
+		}
+		hasMaxBytesReader = hasMaxBytesReader || strings.Contains(line, "MaxBytesReader")
+		hasRequestInputInFile = hasRequestInputInFile || reRequestInput.MatchString(line)
+		hasFormulaSanitizer = hasFormulaSanitizer || reFormulaSanitizer.MatchString(line)
+		if graphqlLibrary == "" {
+			for _, gs := range graphqlServers {
+				if gs.re.MatchString(line) {
+					graphqlLine, graphqlLibrary = i+1, gs.library
+					break
+				}
+			}
 		}
+		hasDepthLimit = hasDepthLimit || reGraphQLDepthLimit.MatchString(line)
+		hasCostLimit = hasCostLimit || reGraphQLCostLimit.MatchString(line)
+		hasBatchDisabled = hasBatchDisabled || reGraphQLBatchDisabled.MatchString(line)
+	}
+
+	// Variables holding request input, for template injection findings, and
+	// those holding it unmodified, for raw query findings.
+	var tainted, wholeRequest map[string]bool
+	if hasRequestInputInFile {
+		tainted = requestTaintedNames(lines)
+		wholeRequest = wholeRequestNames(lines)
+	}
+
+	// Hand-written recursive merges, for prototype pollution findings.
+	var mergers map[string]*regexp.Regexp
+	if hasRequestInputInFile && jsExtensions[ext] {
+		mergers = recursiveMergers(lines)
+	}
+
+	// GraphQL upload surface, reported as ATTACK-004 in place of the
+	// generic upload match on the same lines.
+	uploads := graphqlUploads(lines, ext)
+
+	// Identifiers bound to literals, for hardcoded crypto key findings.
+	cryptoBindings := cryptoLiteralBindings(lines)
+
+	// Ktor nests routes inside route("/prefix") { ... } blocks.
+	var prefixes *routePrefixTracker
+	if ext == ".kt" {
+		prefixes = &routePrefixTracker{}
+	}
+
+	// ASP.NET controllers prefix action routes with the class [Route].
+	var controllers *controllerRouteTracker
+	if ext == ".cs" {
+		controllers = &controllerRouteTracker{}
+	}
+
+	// Spring MVC controllers prefix handler mappings with the class-level
+	// @RequestMapping, and mapping annotations often span several lines.
+	var springs *springControllerTracker
+	if ext == ".java" {
+		springs = &springControllerTracker{}
+	}
+
+	// Rails nests routes in namespace, scope, and resources blocks, and
+	// resources registers several routes on one line.
+	var rails *railsRouteTracker
+	if ext == ".rb" {
+		rails = newRailsRouteTracker(filePath, lines)
+	}
+
+	// Endpoints by line index, for findings about the route a line decorates.
+	endpointsByLine := make(map[int]string)
+
+	// Whole-model responses are not reported when the file controls which
+	// fields are serialized.
+	hasFieldAllowlistInFile := anyLineMatches(lines, reFileFieldAllowlist)
+
+	// Test files are searched for endpoint references, and their own
+	// routes are not expected to be tested.
+	testFile := isTestFile(filePath)
+	if opts.tests != nil && testFile {
+		opts.tests.collect(lines)
+	}
+
+	// References from test files do not make a route reachable.
+	var buildTags string
+	if !testFile {
+		opts.reach.collect(lines, ext)
+		if ext == ".go" {
+			buildTags = buildConstraint(lines)
+		}
+	}
+
+	// Server-wide request timeouts bound every handler in the file.
+	hasServerTimeoutInFile := anyLineMatches(lines, reServerTimeout)
+
+	// CAPTCHA and rate limiting anywhere in the file cover its routes.
+	antiAutomationMissing := missingAntiAutomation(lines)
+
+	// Files using Flask's session without a server-side store keep it in
+	// a signed cookie.
+	flaskCookieSessions := ext == ".py" && anyLineMatches(lines, reFlaskSessionImport) && !anyLineMatches(lines, reServerSideSession)
+	// Go's regexp cannot backtrack, so ReDoS checks skip Go files.
+	var inputRegexes map[string]bool
+	if ext != ".go" {
+		inputRegexes = redosInputRegexes(lines)
+	}
+	hasWebSocketInFile := anyLineMatches(lines, reWebSocket)
+	wsConnectionChecked := hasWebSocketInFile && wsConnectionAuth(lines)
+
+	// Auth middleware registered for every route, a router group, a path
+	// prefix, or a block of routes; otherwise each route needs its own.
+	auth := scanFileAuth(lines)
+
+	// Definition lines of the functions named handlers resolve to, and of
+	// the function enclosing each line.
+	defs := handlerDefinitions(lines)
+	starts := functionStarts(lines, ext)
+
+	// Variables holding client-controllable trust headers.
+	headerVars := trustHeaderVars(lines)
+	lastEndpoint := ""
+
+	// Second pass: find endpoints.
+	for i, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lineNum = i + 1
+
+		routeLine, routeEnd := line, i
+		if springs != nil {
+			routeLine, routeEnd = springAnnotation(lines, i)
+		}
+		method, endpoint, framework := extractRoute(routeLine, ext)
+		pattern := ""
+		if isRegexRoute(endpoint) {
+			pattern, endpoint = endpoint, simplifyRegexRoute(endpoint)
+		}
+		if prefixes != nil {
+			endpoint = prefixes.apply(line, endpoint)
+		}
+		if controllers != nil {
+			endpoint = controllers.apply(lines, i, endpoint)
+		}
+		if springs != nil {
+			endpoint = springs.apply(lines, i, routeEnd, endpoint)
+		}
+		routes := []extractedRoute{{method: method, endpoint: endpoint}}
+		if rails != nil {
+			routes = rails.apply(line, method, endpoint)
+			method, endpoint = "", ""
+			if len(routes) > 0 {
+				method, endpoint = routes[0].method, routes[0].endpoint
+			}
+		}
+		for j, r := range routes {
+			if r.endpoint == "" {
+				continue
+			}
+			opts.routes.add(r.method, r.endpoint, filePath, lineNum)
+			if opts.inventory != nil && (j == 0 || r.endpoint != routes[j-1].endpoint) {
+				opts.inventory.record(r.endpoint, pattern, framework, filePath, lineNum)
+			}
+			if opts.tests != nil && !testFile {
+				opts.tests.record(r.method, r.endpoint, filePath, lineNum)
+			}
+		}
+		if endpoint != "" {
+			endpointsByLine[i] = endpoint
+			lastEndpoint = endpoint
+		}
+		opts.methods.observe(line, method, filePath, lineNum)
+		if endpoint != "" && !testFile {
+			opts.reach.record(filePath, lines, starts, i, ext, buildTags)
+		}
+
+		// ATTACK-080: List endpoint returning an unbounded result set.
+		if (method == "GET" || method == "ANY") && endpoint != "" && isCollectionEndpoint(endpoint) {
+			if query, queryPattern := unboundedListQuery(lines, defs, i, ext); query != "" {
+				newFinding(
+					resp,
+					"ATTACK-080",
+					fmt.Sprintf("List endpoint %s returns an unbounded result set (%s): %s", endpoint, queryPattern, query),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("endpoint", endpoint).
+					WithMetadata("query_pattern", queryPattern).
+					WithMetadata("query", query).
+					Done()
+			}
+		}
+		// ATTACK-095: Request parameter reflected into the response body.
+		// A bare Python return reflects only in a decorated route view.
+		if param, sink := reflectedParameter(line, ext == ".py"); param != "" && hasRequestInputInFile && !testFile {
+			if handler, route := reflectionHandler(lines, starts, i, ext, endpointsByLine); sink != "return" || route {
+				rule := rulesByID["ATTACK-095"]
+				severity := rule.Severity
+				kind := responseType(sink, handler)
+				if kind == "json" {
+					severity = sdk.SeverityInfo
+				}
+				resp.Finding(
+					rule.ID,
+					severity,
+					rule.Confidence,
+					fmt.Sprintf("Request parameter %s reflected into a %s response by %s without encoding: %s", param, kind, sink, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("parameter", param).
+					WithMetadata("response_type", kind).
+					WithMetadata("sink", sink).
+					Done()
+			}
+		}
+		// ATTACK-097: Response wrapped in a user-supplied JSONP callback.
+		if !testFile {
+			if param, wrapper := jsonpCallback(lines, starts, i, ext); param != "" {
+				newFinding(
+					resp,
+					"ATTACK-097",
+					fmt.Sprintf("Response wrapped in a JSONP callback named by request parameter %s (%s): %s", param, wrapper, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("callback_param", param).
+					WithMetadata("wrapper", wrapper).
+					Done()
+			}
+		}
+		// ATTACK-100: ReDoS-prone regex applied to request input.
+		if ext != ".go" && !testFile {
+			reportReDoS(resp, filePath, lines, i, inputRegexes)
+		}
+
+		// ATTACK-098: Profiling or metrics handler registered in code.
+		if !testFile {
+			reportProfilingExposure(resp, filePath, lines, i, hasAuthInFile)
+		}
+		// ATTACK-085: Handler I/O without a timeout or request context.
+		if endpoint != "" && method != "MOUNT" && !hasServerTimeoutInFile {
+			if call, label := ioWithoutTimeout(lines, defs, i, ext); call != "" {
+				newFinding(
+					resp,
+					"ATTACK-085",
+					fmt.Sprintf("Handler for %s performs I/O (%s) without a timeout or request context: %s", endpoint, label, call),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("endpoint", endpoint).
+					WithMetadata("io_call", label).
+					WithMetadata("call", call).
+					Done()
+			}
+		}
+		// ATTACK-094: Registration-like endpoint without anti-automation.
+		if (method == "POST" || method == "PUT" || method == "ANY") && !testFile && len(antiAutomationMissing) > 0 && isSignupEndpoint(endpoint) {
+			newFinding(resp, "ATTACK-094", antiAutomationMessage(method, endpoint, antiAutomationMissing)).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("endpoint", endpoint).
+				WithMetadata("missing", strings.Join(antiAutomationMissing, ",")).
+				Done()
+		}
+		for j, r := range routes {
+			method, endpoint := r.method, r.endpoint
+			// Routes expanded from one line (Rails resources) report the
+			// per-endpoint rules once, with the first method's route.
+			repeat := j > 0 && endpoint == routes[j-1].endpoint
+			drift := ""
+			if endpoint != "" && opts.baseline != nil {
+				if !opts.baseline.observe(endpoint) {
+					// Unchanged relative to the baseline: not drift.
+					endpoint = ""
+				} else {
+					drift = "added"
+				}
+			}
+			// Mounts attach middleware or a sub-router under a prefix rather
+			// than handle requests, so no endpoint rule applies to them.
+			if method == "MOUNT" {
+				continue
+			}
+			if endpoint != "" {
+				external := opts.rewrites.externalPath(endpoint)
+
+				// ATTACK-001: HTTP endpoint detected.
+				f := newFinding(
+					resp,
+					"ATTACK-001",
+					fmt.Sprintf("HTTP %s endpoint detected: %s", method, endpoint),
+				).
+					At(filePath, lineNum, lineNum)
+				f.WithMetadata("method", method).
+					WithMetadata("framework", framework)
+				if pattern != "" {
+					f.WithMetadata("regex_route", "true").
+						WithMetadata("route_pattern", pattern)
+				}
+				withSensitivity(f, opts.sensitivity, endpoint, routeHandlerName(lines, i))
+				withEndpoint(f, endpoint, external, drift).Done()
+				if repeat {
+					continue
+				}
+
+				// ATTACK-002: Check if endpoint lacks auth.
+				if !auth.covers(lines, i, endpoint) && !hasRouteAuth(lines, i, ext) && !opts.publicEndpoints.public(endpoint) {
+					rule := rulesByID["ATTACK-002"]
+					confidence := scoreConfidence(rule.Confidence,
+						countSignals(reSensitivePath.MatchString(endpoint)),
+						countSignals(hasAuthHintInFile),
+					)
+					f := resp.Finding(
+						rule.ID,
+						rule.Severity,
+						confidence,
+						fmt.Sprintf("Potentially unauthenticated endpoint: %s", endpoint),
+					).
+						At(filePath, lineNum, lineNum)
+					withEndpoint(f, endpoint, external, drift).Done()
+				}
+
+				// ATTACK-003: Admin/debug endpoint.
+				if reAdminDebug.MatchString(endpoint) {
+					f := newFinding(
+						resp,
+						"ATTACK-003",
+						fmt.Sprintf("Admin/debug endpoint exposed: %s", endpoint),
+					).
+						At(filePath, lineNum, lineNum)
+					withEndpoint(f, endpoint, external, drift).Done()
+				}
+
+				// ATTACK-064: Webhook receiver without signature verification.
+				body := handlerBody(lines, defs, i, ext)
+				if header := webhookSignatureHeader(body); reWebhookRoute.MatchString(endpoint) || header != "" {
+					if !anyLineMatches(body, reWebhookVerify) {
+						f := newFinding(
+							resp,
+							"ATTACK-064",
+							fmt.Sprintf("Webhook endpoint %s does not verify the request signature", endpoint),
+						).
+							At(filePath, lineNum, lineNum)
+						if header != "" {
+							f.WithMetadata("signature_header", header)
+						}
+						withEndpoint(f, endpoint, external, drift).Done()
+					}
+				}
+
+				// ATTACK-054: Health endpoint disclosing internals.
+				if reHealthRoute.MatchString(endpoint) {
+					if detail := healthDetail(body); detail != "" {
+						f := newFinding(
+							resp,
+							"ATTACK-054",
+							fmt.Sprintf("Health endpoint %s discloses internal detail (%s)", endpoint, detail),
+						).
+							At(filePath, lineNum, lineNum).
+							WithMetadata("detail", detail)
+						withEndpoint(f, endpoint, external, drift).Done()
+					}
+				}
+			}
+		}
+
+		// ATTACK-004: File upload handling.
+		if u, ok := uploads[i]; ok {
+			if u != nil {
+				reportGraphQLUpload(resp, filePath, lineNum, line, u)
+			}
+		} else if reFileUpload.MatchString(line) {
+			// Handlers that receive a file without an extension or MIME
+			// allowlist accept any file type.
+			if reUploadReceive.MatchString(line) {
+				rule := rulesByID["ATTACK-004"]
+				severity := sdk.SeverityMedium
+				message := fmt.Sprintf("File upload accepted without an extension or content-type allowlist: %s", strings.TrimSpace(line))
+				check := uploadAllowlist(uploadScope(lines, defs, starts, i, ext, endpoint != ""))
+				if check != "" {
+					severity = rule.Severity
+					message = fmt.Sprintf("File upload handling detected: %s", strings.TrimSpace(line))
+				}
+				f := resp.Finding(rule.ID, severity, rule.Confidence, message).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("allowlist_check", strconv.FormatBool(check != ""))
+				if check != "" {
+					f.WithMetadata("allowlist", check)
+				}
+				f.Done()
+			} else {
+				newFinding(
+					resp,
+					"ATTACK-004",
+					fmt.Sprintf("File upload handling detected: %s", strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					Done()
+			}
+		}
+
+		// ATTACK-068: Upload stored under the client-supplied filename.
+		if hasUploadInFile {
+			if dest := uploadDestination(line); dest != "" {
+				newFinding(
+					resp,
+					"ATTACK-068",
+					fmt.Sprintf("Upload stored at a path derived from the client filename: %s", dest),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("destination", dest).
+					Done()
+			}
+		}
+
+		// ATTACK-075: Upload directories served back from the app origin,
+		// reported after the walk.
+		opts.served.observe(line, filePath, lineNum, hasUploadInFile)
+
+		// ATTACK-005: WebSocket endpoint.
+		if reWebSocket.MatchString(line) {
+			newFinding(
+				resp,
+				"ATTACK-005",
+				fmt.Sprintf("WebSocket endpoint detected: %s", strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				Done()
+		}
+
+		// ATTACK-099: State-changing WebSocket message without an auth or
+		// origin check.
+		if hasWebSocketInFile && !testFile {
+			reportUnauthorizedWSMessage(resp, filePath, lines, defs, starts, i, ext, wsConnectionChecked)
+		}
+
+		// ATTACK-006: Request input used to build an outbound URL.
+		if hasOutboundCallInFile && reRequestInput.MatchString(line) {
+			if control := ssrfControl(line); control != "" {
+				rule := rulesByID["ATTACK-006"]
+				severity := rule.Severity
+				if control == "host" {
+					severity = sdk.SeverityCritical
+				}
+				resp.Finding(
+					rule.ID,
+					severity,
+					rule.Confidence,
+					fmt.Sprintf("Request input controls outbound URL %s (SSRF): %s", control, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("control", control).
+					Done()
+			}
+		}
+
+		// ATTACK-056: Request body limit set too high or disabled.
+		if setting, limit := excessiveBodyLimit(line); setting != "" {
+			newFinding(
+				resp,
+				"ATTACK-056",
+				fmt.Sprintf("Request body size limit %s is %s", setting, limit),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("setting", setting).
+				WithMetadata("limit", limit).
+				Done()
+		}
+
+		// ATTACK-057: NoSQL query built from request input.
+		if hasEndpointInFile {
+			if sink := nosqlSink(line); sink != "" {
+				driver := mongoDriver
+				if driver == "" {
+					driver = "mongodb"
+				}
+				newFinding(
+					resp,
+					"ATTACK-057",
+					fmt.Sprintf("NoSQL query built from request input (%s, %s): %s", driver, sink, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("driver", driver).
+					WithMetadata("sink", sink).
+					Done()
+			}
+		}
+
+		// ATTACK-074: Query executed exactly as received from the request.
+		if hasEndpointInFile && hasRequestInputInFile {
+			if datastore := rawQuerySink(line, lines, wholeRequest); datastore != "" {
+				newFinding(
+					resp,
+					"ATTACK-074",
+					fmt.Sprintf("Handler executes a %s query taken wholesale from request input: %s", datastore, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("datastore", datastore).
+					Done()
+			}
+		}
+
+		// ATTACK-078: Whole model serialized into the response.
+		if hasEndpointInFile && !hasFieldAllowlistInFile {
+			if model, serializer := wholeModelResponse(line); model != "" {
+				f := newFinding(
+					resp,
+					"ATTACK-078",
+					fmt.Sprintf("Handler returns the whole %s object without a field allowlist: %s", model, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("model", model).
+					WithMetadata("serializer", serializer)
+				if lastEndpoint != "" {
+					f.WithMetadata("endpoint", lastEndpoint)
+				}
+				f.Done()
+			}
+		}
+
+		// ATTACK-079: Security decision based on a client-supplied header.
+		if header := trustHeaderBranch(line, headerVars); header != "" {
+			newFinding(
+				resp,
+				"ATTACK-079",
+				fmt.Sprintf("Security decision trusts client-supplied header %s: %s", header, strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("header", header).
+				WithMetadata("signal", headerSignal(header)).
+				Done()
+		}
+
+		// ATTACK-066: LDAP filter built from request input. The input may be
+		// read on an earlier line of the handler.
+		if hasEndpointInFile && hasRequestInputInFile && ldapLibrary != "" && ldapFilterInjection(line) {
+			newFinding(
+				resp,
+				"ATTACK-066",
+				fmt.Sprintf("LDAP filter built from request input (%s): %s", ldapLibrary, strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("library", ldapLibrary).
+				Done()
+		}
+
+		// ATTACK-067: Template compiled from request input (SSTI).
+		if hasRequestInputInFile {
+			if engine := templateInjection(line, tainted); engine != "" {
+				newFinding(
+					resp,
+					"ATTACK-067",
+					fmt.Sprintf("Template source derived from request input (%s): %s", engine, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("engine", engine).
+					Done()
+			}
+		}
+
+		// ATTACK-083: Request input deep-merged into an object (JS/TS).
+		if hasRequestInputInFile && jsExtensions[ext] {
+			if function := prototypePollution(line, tainted, mergers); function != "" {
+				newFinding(
+					resp,
+					"ATTACK-083",
+					fmt.Sprintf("Request input merged into an object with %s (prototype pollution): %s", function, strings.TrimSpace(line)),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("merge_function", function).
+					Done()
+			}
+		}
+
+		// ATTACK-062: Password hashed with a fast digest.
+		if algorithm := weakPasswordHash(line); algorithm != "" {
+			newFinding(
+				resp,
+				"ATTACK-062",
+				fmt.Sprintf("Password hashed with weak algorithm %s: %s", algorithm, strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("algorithm", algorithm).
+				Done()
+		}
+
+		// ATTACK-070: Non-cryptographic RNG for security-sensitive values.
+		if source := insecureRandom(line, ext, hasGoMathRand); source != "" {
+			newFinding(
+				resp,
+				"ATTACK-070",
+				fmt.Sprintf("Security-sensitive value generated with non-cryptographic RNG %s: %s", source, strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("rng_source", source).
+				Done()
+		}
+
+		// ATTACK-072: Hardcoded API base URLs, reported per host after the walk.
+		opts.apiURLs.scanLine(line, filePath, lineNum)
+
+		// ATTACK-073: Disabled or permissive Content-Security-Policy.
+		opts.csp.observe(line, filePath, lineNum)
+		reportCSPIssues(resp, filePath, lines, i, hasCSPInFile)
+
+		// ATTACK-063: Cloud credentials embedded in source.
+		// ATTACK-076: Other PEM private keys and certificates.
+		if !reportCloudCredential(resp, filePath, lineNum, line) {
+			reportPEMBlock(resp, filePath, lines, i)
+		}
+
+		// ATTACK-086: Basic-auth credentials in URLs and client calls.
+		reportBasicAuthCredential(resp, filePath, lines, i)
+
+		// ATTACK-090: Cipher or MAC keyed with a literal key or IV.
+		reportHardcodedCrypto(resp, filePath, lines, i, cryptoBindings)
+
+		// ATTACK-093: Credentialed CORS for any origin.
+		reportCORSCredentials(resp, filePath, lines, i)
+
+		// ATTACK-091: Secret compared without a constant-time function.
+		if !testFile {
+			reportTimingUnsafeCompare(resp, filePath, lines, i)
+		}
+
+		// ATTACK-096: Pickled or client-side signed session backends.
+		if ext == ".py" {
+			reportUnsafeSessionBackend(resp, filePath, lines, i, flaskCookieSessions)
+		}
+
+		// ATTACK-059: Server bound to all interfaces.
+		if addr := bindAllAddress(line); addr != "" {
+			rule := rulesByID["ATTACK-059"]
+			severity := rule.Severity
+			message := fmt.Sprintf("Server binds to all interfaces (%s)", addr)
+			if hasAdminSurfaceInFile {
+				severity = sdk.SeverityMedium
+				message = fmt.Sprintf("Server with admin/debug surface binds to all interfaces (%s)", addr)
+			}
+			resp.Finding(rule.ID, severity, rule.Confidence, message).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("bind_address", addr).
+				WithMetadata("admin_surface", strconv.FormatBool(hasAdminSurfaceInFile)).
+				Done()
+		}
+
+		// ATTACK-052: Handler that bypasses router registration.
+		if ext == ".go" {
+			if m := reGoServeHTTP.FindStringSubmatch(line); len(m) > 1 {
+				newFinding(
+					resp,
+					"ATTACK-052",
+					fmt.Sprintf("Custom ServeHTTP handler on type %s may route requests internally", m[1]),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("type", m[1]).
+					Done()
+			}
+		}
+
+		// ATTACK-050: Sensitive request data logged alongside route handlers.
+		if hasEndpointInFile && logsSensitiveData(line) {
+			statement := strings.TrimSpace(line)
+			newFinding(
+				resp,
+				"ATTACK-050",
+				fmt.Sprintf("Sensitive request data logged: %s", statement),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("statement", statement).
+				Done()
+		}
+	}
+
+	// ATTACK-055: Spreadsheet export of request data without formula
+	// neutralization.
+	if spreadsheetLibrary != "" && hasEndpointInFile && hasRequestInputInFile && !hasFormulaSanitizer {
+		newFinding(
+			resp,
+			"ATTACK-055",
+			fmt.Sprintf("CSV/spreadsheet export (%s) writes request data without formula neutralization", spreadsheetLibrary),
+		).
+			At(filePath, spreadsheetLine, spreadsheetLine).
+			WithMetadata("library", spreadsheetLibrary).
+			Done()
+	}
+
+	// ATTACK-056: Go handlers reading request bodies without any limit.
+	if goBodyReadLine > 0 && hasEndpointInFile && !hasMaxBytesReader {
+		newFinding(
+			resp,
+			"ATTACK-056",
+			"Request body read without http.MaxBytesReader size limit",
+		).
+			At(filePath, goBodyReadLine, goBodyReadLine).
+			WithMetadata("setting", "MaxBytesReader").
+			WithMetadata("limit", "none").
+			Done()
+	}
+
+	// ATTACK-051: GraphQL server without query depth/cost/batch limits.
+	if graphqlLibrary != "" {
+		var missing []string
+		if !hasDepthLimit {
+			missing = append(missing, "depth limit")
+		}
+		if !hasCostLimit {
+			missing = append(missing, "cost analysis")
+		}
+		if !hasBatchDisabled {
+			missing = append(missing, "batch disabling")
+		}
+		if len(missing) > 0 {
+			newFinding(
+				resp,
+				"ATTACK-051",
+				fmt.Sprintf("GraphQL server (%s) lacks DoS protections: %s", graphqlLibrary, strings.Join(missing, ", ")),
+			).
+				At(filePath, graphqlLine, graphqlLine).
+				WithMetadata("library", graphqlLibrary).
+				WithMetadata("missing", strings.Join(missing, ",")).
+				Done()
+		}
+	}
+
+	// ATTACK-069: Request-derived content written without a safe
+	// Content-Type.
+	if hasRequestInputInFile || ext == ".go" {
+		for _, w := range reflectedWrites(lines, defs, ext, tainted) {
+			message := "Handler writes request-derived content without setting Content-Type"
+			if w.contentType != "missing" {
+				message = fmt.Sprintf("Handler writes request-derived content as %s", w.contentType)
+			}
+			newFinding(resp, "ATTACK-069", message).
+				At(filePath, w.line, w.line).
+				WithMetadata("content_type", w.contentType).
+				Done()
+		}
+	}
+
+	// ATTACK-065: GraphQL mutations without an auth check.
+	reportGraphQLMutations(resp, filePath, ext, lines)
+
+	// ATTACK-071: Explicit CSRF/auth/rate-limit opt-outs.
+	reportSecurityOptOuts(resp, filePath, lines, endpointsByLine)
+
+	// ATTACK-081: Login handlers that keep the pre-login session ID.
+	reportSessionFixation(resp, filePath, ext, lines, defs, endpointsByLine)
+
+	// ATTACK-088: gRPC servers without TLS credentials.
+	// ATTACK-089: gRPC server reflection.
+	reportGRPCServers(resp, filePath, ext, lines)
+
+	if opts.coverage != nil {
+		opts.coverage.record(filePath, fileFramework, fileFrameworkLine, len(endpointsByLine))
+	}
+
+	return nil
+}
+
+// extractEndpoint tries to extract an HTTP endpoint path from a line.
+func extractEndpoint(line, ext string) string {
+	_, endpoint, _ := extractRoute(line, ext)
+	return endpoint
+}
+
+// extractRoute tries to extract the HTTP method and endpoint path from a
+// line. The method is "ANY" when the registration accepts every method and
+// "MOUNT" for prefixes that mount middleware or sub-routers rather than
+// handle requests. framework names the extractor that matched. Lines
+// without any of the language's route keywords are rejected before the
+// route patterns run, and matches whose path fails validEndpoint are
+// discarded.
+func extractRoute(line, ext string) (method, endpoint, framework string) {
+	if !hasRouteKeyword(line, ext) {
+		return "", "", ""
+	}
+	method, endpoint, framework = matchRoute(line, ext)
+	if endpoint != "" && !validEndpoint(endpoint, framework) {
+		return "", "", ""
+	}
+	return method, endpoint, framework
+}
+
+// hasRouteKeyword is a cheap pre-filter for extractRoute: it reports
+// whether line contains a literal that every route pattern for ext
+// requires. Keep routeKeywords in sync when adding or changing patterns.
+func hasRouteKeyword(line, ext string) bool {
+	keywords, ok := routeKeywords[ext]
+	if !ok {
+		return true
+	}
+	for _, kw := range keywords {
+		if strings.Contains(line, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoute runs the route patterns for ext against line. framework
+// identifies the pattern that matched, e.g. "go-gin" or "py-flask".
+func matchRoute(line, ext string) (method, endpoint, framework string) {
+	switch ext {
+	case ".go":
+		if m := reGoHTTPHandle.FindStringSubmatch(line); len(m) > 1 {
+			return "ANY", m[1], "go-net-http"
+		}
+		if m := reGoGinRoute.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "go-gin"
+		}
+		if m := reGoEchoRoute.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "go-echo"
+		}
+		if m := reGoChiRoute.FindStringSubmatch(line); len(m) > 2 {
+			if m[1] == "Route" || m[1] == "Mount" {
+				return "MOUNT", m[2], "go-chi"
+			}
+			return routeMethod(m[1]), m[2], "go-chi"
+		}
+	case ".py":
+		if m := rePyFlask.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "py-flask"
+		}
+		if m := rePyDjango.FindStringSubmatch(line); len(m) > 1 {
+			return "ANY", m[1], "py-django"
+		}
+		if m := rePyFastAPI.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "py-fastapi"
+		}
+		if m := rePyTornado.FindStringSubmatch(line); len(m) > 1 {
+			return "ANY", m[1], "py-tornado"
+		}
+	case ".js", ".ts", ".jsx", ".tsx":
+		if m := reJSExpress.FindStringSubmatch(line); len(m) > 2 {
+			if m[1] == "use" {
+				return "MOUNT", m[2], "js-express"
+			}
+			return routeMethod(m[1]), m[2], "js-express"
+		}
+		if m := reJSKoa.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "js-koa"
+		}
+		if m := reJSFastify.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(m[1]), m[2], "js-fastify"
+		}
+		if m := reJSRegexRoute.FindStringSubmatch(line); len(m) > 2 {
+			if m[1] == "use" {
+				return "MOUNT", m[2], "js-express"
+			}
+			return routeMethod(m[1]), m[2], "js-express"
+		}
+	case ".kt":
+		if m := reKtorRoute.FindStringSubmatch(line); len(m) > 2 {
+			if m[2] == "" {
+				return routeMethod(m[1]), "/", "kt-ktor"
+			}
+			return routeMethod(m[1]), m[2], "kt-ktor"
+		}
+		if m := reKtAnnotation.FindStringSubmatch(line); len(m) > 2 {
+			return routeMethod(strings.TrimSuffix(m[1], "Mapping")), m[2], annotationFramework(m[1])
+		}
+	case ".cs":
+		if m := reCsMinimalAPI.FindStringSubmatch(line); len(m) > 2 {
+			switch m[1] {
+			case "Group":
+				return "MOUNT", m[2], "cs-minimal-api"
+			case "", "Methods":
+				return "ANY", m[2], "cs-minimal-api"
+			}
+			return routeMethod(m[1]), m[2], "cs-minimal-api"
+		}
+		if m := reCsHTTPAttribute.FindStringSubmatch(line); len(m) > 2 {
+			if m[2] == "" {
+				// [HttpGet] without a template handles the controller route.
+				return routeMethod(m[1]), "/", "cs-aspnet-mvc"
+			}
+			return routeMethod(m[1]), m[2], "cs-aspnet-mvc"
+		}
+		if m := reCsRouteAttribute.FindStringSubmatch(line); len(m) > 1 {
+			return "ANY", m[1], "cs-aspnet-mvc"
+		}
+	case ".java":
+		if method, endpoint := matchSpringMapping(line); endpoint != "" {
+			return method, endpoint, "java-spring"
+		}
+	case ".rb":
+		if method, endpoint := matchRailsRoute(line); endpoint != "" {
+			return method, endpoint, "rb-rails"
+		}
+	}
+	return "", "", ""
+}
+
+// annotationFramework tells Spring mapping annotations (@GetMapping,
+// @RequestMapping) from Micronaut's (@Get, @Post).
+func annotationFramework(annotation string) string {
+	if strings.HasSuffix(annotation, "Mapping") {
+		return "kt-spring"
+	}
+	return "kt-micronaut"
+}
+
+// routeMethod normalizes a framework verb (get, Post, Any, route, all,
+// Request) to an upper-case HTTP method or "ANY".
+func routeMethod(verb string) string {
+	switch upper := strings.ToUpper(verb); upper {
+	case "ANY", "ALL", "ROUTE", "REQUEST":
+		return "ANY"
+	default:
+		return upper
+	}
+}
+
+// routePrefixTracker follows brace depth through a file so that endpoints
+// declared inside Ktor route("/prefix") { ... } blocks get their full path.
+type routePrefixTracker struct {
+	depth  int
+	frames []prefixFrame
+}
+
+// prefixFrame is an open route block and the depth it was opened at.
+type prefixFrame struct {
+	path  string
+	depth int
+}
+
+// apply returns endpoint joined with the enclosing route prefixes and then
+// advances the tracker past line.
+func (t *routePrefixTracker) apply(line, endpoint string) string {
+	if endpoint != "" {
+		for i := len(t.frames) - 1; i >= 0; i-- {
+			endpoint = joinRoutePath(t.frames[i].path, endpoint)
+		}
+	}
+
+	if m := reKtorRouteBlock.FindStringSubmatch(line); len(m) > 1 {
+		t.frames = append(t.frames, prefixFrame{path: m[1], depth: t.depth})
+	}
+
+	t.depth += strings.Count(line, "{") - strings.Count(line, "}")
+	for len(t.frames) > 0 && t.depth <= t.frames[len(t.frames)-1].depth {
+		t.frames = t.frames[:len(t.frames)-1]
+	}
+	return endpoint
+}
+
+// joinRoutePath joins a route prefix and a (possibly relative) path.
+func joinRoutePath(prefix, path string) string {
+	if path == "/" || path == "" {
+		return prefix
+	}
+	return strings.TrimRight(prefix, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// confidenceLevels orders confidence values from weakest to strongest.
+var confidenceLevels = []pluginv1.Confidence{
+	sdk.ConfidenceLow,
+	sdk.ConfidenceMedium,
+	sdk.ConfidenceHigh,
+}
+
+// scoreConfidence adjusts a rule's base confidence by corroborating and
+// contradicting signals. Each corroborating signal raises confidence one
+// level and each contradicting signal lowers it one level; the result is
+// clamped to the Low..High range.
+//
+// For ATTACK-002 the inputs are:
+//   - corroborating: the endpoint path suggests sensitive data (/admin,
+//     /users, /billing, ...), given that no auth middleware covers the
+//     route.
+//   - contradicting: the file contains partial auth signals (tokens,
+//     sessions, role annotations) that are not recognized middleware.
+func scoreConfidence(base pluginv1.Confidence, corroborating, contradicting int) pluginv1.Confidence {
+	level := 0
+	for i, c := range confidenceLevels {
+		if c == base {
+			level = i
+		}
+	}
+	level += corroborating - contradicting
+	level = max(0, min(level, len(confidenceLevels)-1))
+	return confidenceLevels[level]
+}
+
+// countSignals returns how many of the given signals are set.
+func countSignals(signals ...bool) int {
+	n := 0
+	for _, s := range signals {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
+// withEndpoint attaches the endpoint path to a finding, along with the
+// externally exposed path when a proxy rewrite applies and the drift status
+// when comparing against a baseline.
+func withEndpoint(f *sdk.FindingBuilder, endpoint, external, drift string) *sdk.FindingBuilder {
+	f.WithMetadata("endpoint", endpoint)
+	if external != "" {
+		f.WithMetadata("external_path", external)
+	}
+	if drift != "" {
+		f.WithMetadata("drift", drift)
+	}
+	return f
+}
+
+// handlerDefinitions maps the name of each function defined in lines to
+// the index of its first definition line, for resolving named handlers.
+func handlerDefinitions(lines []string) map[string]int {
+	defs := make(map[string]int)
+	for j, line := range lines {
+		for _, m := range reHandlerDef.FindAllStringSubmatch(line, -1) {
+			if _, ok := defs[m[1]]; !ok {
+				defs[m[1]] = j
+			}
+		}
+	}
+	return defs
+}
+
+// handlerBody returns the lines of the handler for the route defined at
+// lines[idx]. Named handlers (http.HandleFunc("/x", handler)) are resolved
+// through defs to their definition in the same file; otherwise the inline
+// body following the route is used. Brace depth bounds the body in C-like
+// languages and indentation bounds it in Python.
+func handlerBody(lines []string, defs map[string]int, idx int, ext string) []string {
+	start := idx
+	if m := reNamedHandler.FindStringSubmatch(lines[idx]); len(m) > 1 {
+		if j, ok := defs[m[1]]; ok {
+			start = j
+		}
+	}
+
+	if ext == ".py" {
+		for j := start; j < len(lines); j++ {
+			m := reIndentedBlock.FindStringSubmatch(lines[j])
+			if m == nil {
+				continue
+			}
+			end := j + 1
+			for end < len(lines) && (strings.TrimSpace(lines[end]) == "" || len(lines[end])-len(strings.TrimLeft(lines[end], " \t")) > len(m[1])) {
+				end++
+			}
+			return lines[start:end]
+		}
+		return lines[start:]
+	}
+
+	return braceBlock(lines, start)
+}
+
+// healthDetail returns the first internal detail (version, host name,
+// dependency status) referenced in a handler body, or "".
+func healthDetail(body []string) string {
+	for _, line := range body {
+		if m := reHealthDetail.FindString(line); m != "" {
+			return m
+		}
+	}
+	return ""
+}
+
+// webhookSignatureHeader returns the webhook signature header a handler
+// body reads, or "".
+func webhookSignatureHeader(body []string) string {
+	for _, line := range body {
+		if m := reWebhookSignature.FindStringSubmatch(line); len(m) > 1 {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// anyLineMatches reports whether re matches any of lines.
+func anyLineMatches(lines []string, re *regexp.Regexp) bool {
+	for _, line := range lines {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// ssrfControl reports which part of an outbound URL built on line is
+// interpolated: "host" when the value follows the scheme directly, "path"
+// when it follows a fixed host, or "" when the URL is not interpolated.
+func ssrfControl(line string) string {
+	switch {
+	case reSSRFHostInterp.MatchString(line):
+		return "host"
+	case reSSRFPathInterp.MatchString(line):
+		return "path"
+	}
+	return ""
+}
+
+// nosqlSink classifies a NoSQL injection sink on line: "$where" for
+// server-side JavaScript evaluated from a variable, "spread" for query
+// objects built by spreading the request, "query" for query calls fed
+// request input directly, or "" when none applies.
+func nosqlSink(line string) string {
+	switch {
+	case reMongoWhere.MatchString(line):
+		return "$where"
+	case reSpreadRequestBody.MatchString(line) && reMongoQuery.MatchString(line):
+		return "spread"
+	case reMongoQuery.MatchString(line) && reRequestInput.MatchString(line):
+		return "query"
 	}
 	return ""
 }
 
+// ldapFilterInjection reports whether line interpolates a value into an
+// LDAP filter without escaping it.
+func ldapFilterInjection(line string) bool {
+	return reLDAPFilterInterp.MatchString(line) && !reLDAPEscape.MatchString(line)
+}
+
+// weakPasswordHash returns the digest algorithm (md5, sha1, sha256, ...)
+// when line applies a fast hash to a password-like value, or "". Lines that
+// also use bcrypt, argon2, scrypt, or PBKDF2 are not reported.
+func weakPasswordHash(line string) string {
+	if !rePasswordVar.MatchString(line) || reStrongKDF.MatchString(line) {
+		return ""
+	}
+	m := reWeakHashCall.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	for _, g := range m[1:] {
+		if g != "" {
+			return strings.ReplaceAll(strings.ToLower(g), "-", "")
+		}
+	}
+	return ""
+}
+
+// bindAllAddress returns the address when line binds a listener to every
+// interface (":8080", "0.0.0.0", "::"), or "".
+func bindAllAddress(line string) string {
+	for _, re := range bindAllPatterns {
+		if m := re.FindStringSubmatch(line); len(m) > 1 {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// logsSensitiveData reports whether a line is a logging call that writes
+// whole request bodies/headers or auth-related values. Calls that pass the
+// value through a redaction helper are ignored.
+func logsSensitiveData(line string) bool {
+	m := reLogCall.FindStringSubmatch(line)
+	if len(m) < 2 {
+		return false
+	}
+	args := m[1]
+	if reLogSanitized.MatchString(args) {
+		return false
+	}
+	return reLogWholeRequest.MatchString(args) || reLogSecretField.MatchString(stripLogLiterals(args))
+}
+
+// stripLogLiterals replaces the string literals in log arguments with the
+// expressions interpolated into them, so that "Password reset email sent"
+// is dropped while f"token={token}" keeps token.
+func stripLogLiterals(args string) string {
+	return reQuotedString.ReplaceAllStringFunc(args, func(lit string) string {
+		var exprs []string
+		for _, m := range reLogInterpolation.FindAllStringSubmatch(lit, -1) {
+			exprs = append(exprs, m[1])
+		}
+		return " " + strings.Join(exprs, " ") + " "
+	})
+}
+
 // isCommonPublicEndpoint returns true for endpoints that are commonly public.
 func isCommonPublicEndpoint(endpoint string) bool {
 	public := []string{"/health", "/healthz", "/ready", "/readyz", "/ping", "/version", "/", "/favicon.ico", "/robots.txt"}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// --- Scan manifest ---
+
+// scanManifest is the manifest_output document: what a scan ran with, so
+// results can be audited and compared between runs.
+type scanManifest struct {
+	Plugin        string            `json:"plugin"`
+	Version       string            `json:"version"`
+	GoVersion     string            `json:"go_version"`
+	Platform      string            `json:"platform"`
+	StartedAt     string            `json:"started_at"`
+	FinishedAt    string            `json:"finished_at"`
+	DurationMS    int64             `json:"duration_ms"`
+	WorkspaceRoot string            `json:"workspace_root"`
+	ConfigFile    string            `json:"config_file,omitempty"`
+	Inputs        map[string]any    `json:"inputs"`
+	InputSources  map[string]string `json:"input_sources"`
+	Rules         []string          `json:"rules"`
+	Findings      int               `json:"findings"`
+}
+
+// manifestRecorder collects the scan's effective configuration when the
+// scan starts and writes the manifest when it ends.
+type manifestRecorder struct {
+	manifest scanManifest
+	start    time.Time
+}
+
+// newManifestRecorder records the effective inputs, after config merging,
+// with the source of each ("input" or "config"). workspace_root is
+// recorded as "." so that the manifest does not leak the runner's paths,
+// unless absolute_paths asks for its absolute form, and webhook_url is cut
+// to its origin.
+func newManifestRecorder(workspaceRoot, configPath string, toolInputs, effective map[string]any, categories *categoryFilter) *manifestRecorder {
+	start := time.Now()
+	m := scanManifest{
+		Plugin:        "nox/attack-surface",
+		Version:       version,
+		GoVersion:     runtime.Version(),
+		Platform:      runtime.GOOS + "/" + runtime.GOARCH,
+		StartedAt:     start.UTC().Format(time.RFC3339),
+		WorkspaceRoot: ".",
+		Inputs:        make(map[string]any, len(effective)),
+		InputSources:  make(map[string]string, len(effective)),
+		Rules:         activeRules(categories),
+	}
+	if absolute, _ := effective["absolute_paths"].(bool); absolute {
+		m.WorkspaceRoot = workspaceRoot
+		if abs, err := filepath.Abs(workspaceRoot); err == nil {
+			m.WorkspaceRoot = abs
+		}
+	}
+	if configPath != "" {
+		m.ConfigFile = relativePath(workspaceRoot, configPath)
+	}
+	for k, v := range effective {
+		if k == "workspace_root" {
+			continue
+		}
+		if s, ok := v.(string); ok && k == "webhook_url" {
+			if u, err := url.Parse(s); err == nil {
+				v = u.Scheme + "://" + u.Host
+			}
+		}
+		m.Inputs[k] = v
+		m.InputSources[k] = "config"
+		if _, ok := toolInputs[k]; ok {
+			m.InputSources[k] = "input"
+		}
+	}
+	return &manifestRecorder{manifest: m, start: start}
+}
+
+// activeRules returns the catalog rule IDs whose findings the category
+// filter keeps, in catalog order.
+func activeRules(categories *categoryFilter) []string {
+	var ids []string
+	for _, r := range ruleCatalog {
+		if categories == nil || (r.Category == categoryDiagnostics && !categories.exclude[r.Category]) || categories.keep(r.Category) {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// write completes the manifest with the end time and finding count and
+// writes it to path as indented JSON.
+func (r *manifestRecorder) write(path string, findings int) error {
+	end := time.Now()
+	r.manifest.FinishedAt = end.UTC().Format(time.RFC3339)
+	r.manifest.DurationMS = end.Sub(r.start).Milliseconds()
+	r.manifest.Findings = findings
+	data, err := json.MarshalIndent(r.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Finding metadata contracts ---
+
+// metadataKind is the format of a metadata value. Values are always
+// strings on the wire; the kind says how to parse them.
+type metadataKind string
+
+const (
+	metaString  metadataKind = "string"
+	metaInteger metadataKind = "integer" // decimal integer, e.g. "3"
+	metaNumber  metadataKind = "number"  // decimal number, e.g. "7.5"
+	metaBoolean metadataKind = "boolean" // "true" or "false"
+	metaList    metadataKind = "list"    // comma-separated values
+	metaJSON    metadataKind = "json"    // JSON-encoded document
+)
+
+// metadataField describes one metadata key a rule may emit.
+type metadataField struct {
+	Key         string
+	Kind        metadataKind
+	Required    bool
+	Description string
+	Enum        []string
+}
+
+// endpointMetadata is set by withEndpoint on route findings.
+var endpointMetadata = []metadataField{
+	{"endpoint", metaString, true, "Route path as written in the source", nil},
+	{"external_path", metaString, false, "Externally exposed path when resolve_proxy_paths maps the route", nil},
+	{"drift", metaString, false, "Change relative to baseline_path", []string{"added", "removed"}},
+	{"reachability", metaString, false, "Set when the route may never be registered at runtime", []string{"uncertain"}},
+	{"reachability_reason", metaString, false, "Why the route's reachability is uncertain", nil},
+}
+
+// commonMetadata is added to findings of any rule by post-processing;
+// only category and fingerprint are set on every finding.
+var commonMetadata = []metadataField{
+	{"category", metaString, true, "Rule category", ruleCategories},
+	{"fingerprint", metaString, true, "Hash of rule, file, endpoint, and line content, stable across line shifts", nil},
+	{"risk_score", metaNumber, false, "0-10 risk score, set when risk_scores is enabled", nil},
+	{"submodule", metaString, false, "Path of the git submodule containing the finding", nil},
+	{"rank", metaInteger, false, "1-based priority among the findings kept by top_n", nil},
+}
+
+// ruleMetadata lists the metadata each catalog rule emits, keyed by rule
+// ID. Every rule in ruleCatalog has an entry; rules without metadata map
+// to nil.
+var ruleMetadata = map[string][]metadataField{
+	"ATTACK-000": {
+		{"error_type", metaString, false, "Kind of scan error", scanErrorKindNames()},
+		{"count", metaInteger, false, "Number of errors of this kind", nil},
+		{"first_error", metaString, false, "Message of the first error", nil},
+		{"paths", metaList, false, "Paths that failed", nil},
+		{"coverage", metaString, false, "Coverage diagnostic type, set when coverage_report is enabled", []string{"summary", "gap"}},
+		{"files_scanned", metaInteger, false, "Source files scanned", nil},
+		{"framework_files", metaInteger, false, "Files importing a web framework", nil},
+		{"files_with_endpoints", metaInteger, false, "Files with at least one endpoint", nil},
+		{"endpoints", metaInteger, false, "Endpoints extracted", nil},
+		{"confidence_high", metaInteger, false, "Findings with high confidence", nil},
+		{"confidence_medium", metaInteger, false, "Findings with medium confidence", nil},
+		{"confidence_low", metaInteger, false, "Findings with low confidence", nil},
+		{"gap_files", metaInteger, false, "Framework files that yielded no endpoints", nil},
+		{"framework", metaString, false, "Framework imported by a coverage gap file", nil},
+		{"suppressed", metaInteger, false, "Findings dropped by suppressions", nil},
+		{"top_n", metaInteger, false, "Number of findings top_n keeps", nil},
+		{"total", metaInteger, false, "Ranked findings before top_n", nil},
+		{"omitted", metaInteger, false, "Findings dropped by top_n", nil},
+	},
+	"ATTACK-001": append(endpointMetadata[:len(endpointMetadata):len(endpointMetadata)],
+		metadataField{"framework", metaString, false, "Extractor that matched the route", nil},
+		metadataField{"regex_route", metaBoolean, false, "Route is a regular expression", nil},
+		metadataField{"route_pattern", metaString, false, "Original regular expression of a regex route", nil},
+		metadataField{"method", metaString, false, "HTTP method the route is registered for (ANY for every method), or a template form submits with", nil},
+		metadataField{"source", metaString, false, "Where the endpoint was found when not a route registration", []string{"template"}},
+		metadataField{"sensitivity", metaList, false, "Data sensitivity categories", nil},
+		metadataField{"sensitivity_keywords", metaList, false, "Keywords that matched the sensitivity categories", nil},
+		metadataField{"issues", metaJSON, false, "Rolled-up findings, set when aggregate_by_endpoint is enabled", nil},
+		metadataField{"issue_count", metaInteger, false, "Number of rolled-up findings", nil},
+	),
+	"ATTACK-002": endpointMetadata,
+	"ATTACK-003": endpointMetadata,
+	"ATTACK-004": {
+		{"graphql", metaBoolean, false, "Upload surface is GraphQL", nil},
+		{"mechanism", metaString, false, "GraphQL upload library or scalar", nil},
+		{"mutation", metaString, false, "GraphQL mutation accepting the upload", nil},
+		{"allowlist_check", metaBoolean, false, "Handler receiving the file checks its extension or content type against an allowlist", nil},
+		{"allowlist", metaString, false, "Extension or content-type check found in the handler", nil},
+	},
+	"ATTACK-005": nil,
+	"ATTACK-006": {
+		{"control", metaString, true, "Part of the outbound URL the request controls", []string{"host", "path"}},
+	},
+	"ATTACK-050": {
+		{"statement", metaString, true, "Logging statement", nil},
+	},
+	"ATTACK-051": {
+		{"library", metaString, true, "GraphQL server library", nil},
+		{"missing", metaList, true, "Missing protections", nil},
+	},
+	"ATTACK-052": {
+		{"type", metaString, true, "Type implementing ServeHTTP", nil},
+	},
+	"ATTACK-053": {
+		{"port", metaString, true, "Exposed debugger port", nil},
+		{"debugger", metaString, true, "Debugger using the port", nil},
+		{"service", metaString, true, "Directory containing the Dockerfile", nil},
+		{"exposed_ports", metaList, true, "All ports the Dockerfile exposes", nil},
+		{"entrypoint", metaString, false, "CMD or ENTRYPOINT", nil},
+	},
+	"ATTACK-054": append(endpointMetadata[:len(endpointMetadata):len(endpointMetadata)],
+		metadataField{"detail", metaString, true, "Internal detail disclosed", nil},
+	),
+	"ATTACK-055": {
+		{"library", metaString, true, "CSV or spreadsheet library", nil},
+	},
+	"ATTACK-056": {
+		{"setting", metaString, true, "Body size setting", nil},
+		{"limit", metaString, true, "Configured limit, or none", nil},
+	},
+	"ATTACK-057": {
+		{"driver", metaString, true, "NoSQL driver", nil},
+		{"sink", metaString, true, "Query construct built from request input", nil},
+	},
+	"ATTACK-058": {
+		{"endpoint", metaString, true, "Shadowed route path", nil},
+		{"method", metaString, true, "HTTP method", nil},
+		{"service", metaString, true, "Service the registrations belong to", nil},
+		{"locations", metaList, true, "file:line of every registration", nil},
+	},
+	"ATTACK-059": {
+		{"bind_address", metaString, true, "Address the server binds to", nil},
+		{"admin_surface", metaBoolean, true, "File also exposes admin/debug surface", nil},
+	},
+	"ATTACK-060": {
+		{"rules", metaList, true, "Coinciding rule IDs", nil},
+		{"endpoint", metaString, false, "Endpoint the rules coincide on", nil},
+	},
+	"ATTACK-061": {
+		{"exposed", metaList, true, "Exposed actuator endpoints, or *", nil},
+		{"endpoint", metaString, true, "Actuator path", nil},
+		{"dangerous", metaList, false, "Dangerous actuator endpoints exposed", nil},
+	},
+	"ATTACK-062": {
+		{"algorithm", metaString, true, "Digest used for the password", nil},
+	},
+	"ATTACK-063": {
+		{"provider", metaString, true, "Cloud provider", nil},
+		{"credential", metaString, true, "Credential type", nil},
+		{"redacted", metaString, true, "Redacted credential", nil},
+	},
+	"ATTACK-064": append(endpointMetadata[:len(endpointMetadata):len(endpointMetadata)],
+		metadataField{"signature_header", metaString, false, "Signature header the handler reads", nil},
+	),
+	"ATTACK-065": {
+		{"mutation", metaString, true, "GraphQL mutation", nil},
+	},
+	"ATTACK-066": {
+		{"library", metaString, true, "LDAP library", nil},
+	},
+	"ATTACK-067": {
+		{"engine", metaString, true, "Template engine", nil},
+	},
+	"ATTACK-068": {
+		{"destination", metaString, true, "Path expression the upload is stored at", nil},
+	},
+	"ATTACK-069": {
+		{"content_type", metaString, true, "Content-Type written, or none", nil},
+	},
+	"ATTACK-070": {
+		{"rng_source", metaString, true, "Non-cryptographic random source", nil},
+	},
+	"ATTACK-071": {
+		{"protection", metaString, true, "Protection disabled", nil},
+		{"mechanism", metaString, true, "Opt-out construct", nil},
+		{"scope", metaString, true, "Reach of the opt-out", []string{"route", "global"}},
+		{"endpoint", metaString, false, "Route the opt-out applies to", nil},
+		{"handler", metaString, false, "Handler the opt-out decorates", nil},
+	},
+	"ATTACK-072": {
+		{"host", metaString, true, "API host", nil},
+		{"host_type", metaString, true, "Host classification", []string{"internal", "production", "external", "cdn"}},
+		{"path", metaString, true, "Path of the first URL", nil},
+		{"url_paths", metaList, true, "Distinct URL paths", nil},
+		{"count", metaInteger, true, "Number of references", nil},
+		{"locations", metaList, true, "file:line of every reference", nil},
+	},
+	"ATTACK-073": {
+		{"issue", metaString, true, "CSP problem", []string{"missing", "disabled", "wildcard", "unsafe-inline", "unsafe-eval"}},
+		{"directive", metaString, false, "CSP directive", nil},
+		{"value", metaString, false, "Offending source or setting", nil},
+	},
+	"ATTACK-074": {
+		{"datastore", metaString, true, "Datastore receiving the raw query", nil},
+	},
+	"ATTACK-075": {
+		{"upload_dir", metaString, true, "Directory uploads are stored in", nil},
+		{"served_dir", metaString, true, "Directory served statically", nil},
+		{"locations", metaList, true, "file:line of the upload and serving code", nil},
+		{"endpoint", metaString, false, "Route serving the uploads", nil},
+	},
+	"ATTACK-076": {
+		{"key_type", metaString, true, "PEM block type", nil},
+		{"kind", metaString, true, "Private key or certificate", []string{"private key", "certificate"}},
+		{"excerpt", metaString, true, "First and last characters of the block body", nil},
+	},
+	"ATTACK-077": {
+		{"score", metaNumber, true, "Weighted attack surface score", nil},
+		{"rank", metaInteger, true, "Rank by score, 1 being highest", nil},
+		{"endpoints", metaInteger, true, "Endpoints in the file", nil},
+		{"unauthenticated", metaInteger, true, "Unauthenticated endpoints in the file", nil},
+		{"uploads", metaInteger, true, "Upload handlers in the file", nil},
+		{"websockets", metaInteger, true, "WebSocket endpoints in the file", nil},
+		{"injection_sinks", metaInteger, true, "Injection sinks in the file", nil},
+	},
+	"ATTACK-078": {
+		{"model", metaString, true, "Serialized model", nil},
+		{"serializer", metaString, true, "Serialization call", nil},
+		{"endpoint", metaString, false, "Most recent route above the call", nil},
+	},
+	"ATTACK-079": {
+		{"header", metaString, true, "Trusted request header", nil},
+		{"signal", metaString, true, "What the header is trusted for", []string{"client-ip", "internal-flag", "identity"}},
+	},
+	"ATTACK-080": {
+		{"endpoint", metaString, true, "List endpoint", nil},
+		{"query_pattern", metaString, true, "Shape of the unbounded query", nil},
+		{"query", metaString, true, "Query line", nil},
+	},
+	"ATTACK-081": {
+		{"framework", metaString, true, "Session store", nil},
+		{"session_write", metaString, true, "Session write storing the user", nil},
+		{"endpoint", metaString, false, "Login route", nil},
+		{"handler", metaString, false, "Login handler", nil},
+	},
+	"ATTACK-082": {
+		{"mechanism", metaString, true, "Method override mechanism", nil},
+		{"controls", metaList, true, "Method-based control kinds", nil},
+		{"locations", metaList, true, "file:line of every control", nil},
+	},
+	"ATTACK-083": {
+		{"merge_function", metaString, true, "Merge or assign function", nil},
+	},
+	"ATTACK-084": {
+		{"handlers", metaList, true, "Inline handler attributes and javascript: URLs", nil},
+	},
+	"ATTACK-085": {
+		{"endpoint", metaString, true, "Route of the handler", nil},
+		{"io_call", metaString, true, "Kind of I/O call", nil},
+		{"call", metaString, true, "I/O call line", nil},
+	},
+	"ATTACK-086": {
+		{"mechanism", metaString, true, "URL userinfo, or the client call or header carrying the credential", nil},
+		{"username", metaString, true, "Username", nil},
+		{"redacted", metaString, true, "Credential with the password masked", nil},
+		{"host", metaString, false, "Host the credential is for", nil},
+	},
+	"ATTACK-087": {
+		{"endpoint", metaString, true, "Endpoint no test refers to", nil},
+		{"method", metaString, false, "HTTP method of the route", nil},
+	},
+	"ATTACK-088": {
+		{"language", metaString, true, "Source language", []string{"go", "python", "javascript", "kotlin", "csharp"}},
+		{"library", metaString, true, "gRPC server library", nil},
+		{"mechanism", metaString, true, "How the server ends up plaintext", []string{"insecure credentials", "no credentials"}},
+		{"auth_interceptor", metaBoolean, true, "File registers a server interceptor", nil},
+	},
+	"ATTACK-089": {
+		{"library", metaString, true, "Library providing the reflection service", nil},
+		{"insecure_transport", metaBoolean, true, "File also sets up a gRPC server without TLS", nil},
+	},
+	"ATTACK-090": {
+		{"algorithm", metaString, true, "Cipher, mode, or MAC being constructed", nil},
+		{"material", metaList, true, "Static material: key, iv, or both", nil},
+		{"redacted", metaString, true, "First static value with all but a short prefix masked", nil},
+	},
+	"ATTACK-091": {
+		{"variable", metaString, true, "Secret operand as written", nil},
+		{"operator", metaString, true, "Operator or function used to compare", nil},
+	},
+	"ATTACK-092": {
+		{"resource", metaString, true, "Terraform resource address (type.name)", nil},
+		{"kind", metaString, true, "What is exposed", []string{"ingress", "load_balancer", "database"}},
+		{"port", metaString, false, "Port, range, comma-separated ports, or all", nil},
+		{"cidr", metaList, false, "Internet-wide sources the rule admits", nil},
+	},
+	"ATTACK-093": {
+		{"origin", metaString, true, "How any origin is allowed", []string{"wildcard", "reflected"}},
+		{"origin_setting", metaString, true, "Setting that allows any origin", nil},
+		{"credentials_setting", metaString, true, "Setting that allows credentials", nil},
+	},
+	"ATTACK-094": {
+		{"endpoint", metaString, true, "Registration-like route path", nil},
+		{"missing", metaList, true, "Protections absent from the file", []string{"captcha", "rate_limit"}},
+	},
+	"ATTACK-095": {
+		{"parameter", metaString, true, "Reflected request parameter", nil},
+		{"response_type", metaString, true, "Body type the parameter is written as", []string{"json", "html", "text"}},
+		{"sink", metaString, true, "Call or return writing the response", nil},
+	},
+	"ATTACK-096": {
+		{"backend", metaString, true, "Session engine, interface, or serializer", nil},
+		{"serialization", metaString, true, "How session data is stored", []string{"pickle", "signed"}},
+		{"session_key", metaString, false, "Sensitive key written to a Flask cookie session", nil},
+	},
+	"ATTACK-097": {
+		{"callback_param", metaString, true, "Request parameter naming the callback", nil},
+		{"wrapper", metaString, true, "Helper or hand-built wrapper producing the JSONP body", []string{"res.jsonp", "c.JSONP", "concatenation"}},
+	},
+	"ATTACK-098": {
+		{"mechanism", metaString, true, "Profiling or metrics mechanism registered", []string{"pprof", "expvar", "prometheus", "inspector"}},
+		{"registration", metaString, true, "Import or call that registers it", nil},
+		{"auth_in_file", metaBoolean, true, "File has auth middleware", nil},
+	},
+	"ATTACK-099": {
+		{"event", metaString, true, "WebSocket event handled, or message for receive loops", nil},
+		{"operation", metaString, true, "State-changing call or statement in the handler", nil},
+	},
+	"ATTACK-100": {
+		{"pattern", metaString, true, "Suspect regular expression", nil},
+		{"construct", metaString, true, "Backtracking construct found", []string{"nested_quantifier", "overlapping_alternation"}},
+		{"applied_to", metaString, true, "How the regex reaches request input", []string{"request_input", "validator"}},
+	},
+}
+
+// scanErrorKindNames returns the scan error kinds as strings.
+func scanErrorKindNames() []string {
+	names := make([]string, len(scanErrorKinds))
+	for i, k := range scanErrorKinds {
+		names[i] = string(k)
+	}
+	return names
+}
+
+// metadataValueSchema returns the JSON Schema of a metadata value.
+func metadataValueSchema(f metadataField) map[string]any {
+	s := map[string]any{"type": "string", "description": f.Description, "x-kind": string(f.Kind)}
+	switch f.Kind {
+	case metaInteger:
+		s["pattern"] = `^-?[0-9]+$`
+	case metaNumber:
+		s["pattern"] = `^-?[0-9]+(\.[0-9]+)?$`
+	case metaBoolean:
+		s["enum"] = []string{"true", "false"}
+	case metaJSON:
+		s["contentMediaType"] = "application/json"
+	}
+	if f.Enum != nil {
+		s["enum"] = f.Enum
+	}
+	return s
+}
+
+// metadataSchema builds a JSON Schema (draft 2020-12) for findings: each
+// rule's metadata is an object of the keys in ruleMetadata plus
+// commonMetadata, with no other keys allowed.
+func metadataSchema() (map[string]any, error) {
+	defs := make(map[string]any, len(ruleCatalog))
+	ruleIDs := make([]string, 0, len(ruleCatalog))
+	branches := make([]any, 0, len(ruleCatalog))
+	for _, r := range ruleCatalog {
+		fields, ok := ruleMetadata[r.ID]
+		if !ok {
+			return nil, fmt.Errorf("rule %s has no metadata contract", r.ID)
+		}
+		properties := make(map[string]any)
+		required := []string{}
+		for _, f := range append(fields[:len(fields):len(fields)], commonMetadata...) {
+			properties[f.Key] = metadataValueSchema(f)
+			if f.Required {
+				required = append(required, f.Key)
+			}
+		}
+		defs[r.ID] = map[string]any{
+			"description":          r.Description,
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+		ruleIDs = append(ruleIDs, r.ID)
+		branches = append(branches, map[string]any{
+			"if":   map[string]any{"properties": map[string]any{"rule_id": map[string]any{"const": r.ID}}},
+			"then": map[string]any{"properties": map[string]any{"metadata": map[string]any{"$ref": "#/$defs/" + r.ID}}},
+		})
+	}
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         "https://github.com/nox-hq/nox-plugin-attack-surface/finding-metadata.schema.json",
+		"title":       "nox-plugin-attack-surface finding metadata",
+		"description": "Metadata keys each rule emits. Values are strings; x-kind gives their format.",
+		"type":        "object",
+		"properties": map[string]any{
+			"rule_id":  map[string]any{"enum": ruleIDs},
+			"metadata": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+		},
+		"required": []string{"rule_id"},
+		"allOf":    branches,
+		"$defs":    defs,
+	}, nil
+}
+
+// handleMetadataSchema writes the finding metadata JSON Schema to the
+// output_path input, expanding environment variables in it like the scan
+// tool's path inputs. It emits no findings.
+func handleMetadataSchema(_ context.Context, req sdk.ToolRequest) (*pluginv1.InvokeToolResponse, error) {
+	path, _ := req.Input["output_path"].(string)
+	if path == "" {
+		return nil, errors.New("output_path is required")
+	}
+	path, err := expandEnv(path)
+	if err != nil {
+		return nil, fmt.Errorf("output_path: %w", err)
+	}
+	schema, err := metadataSchema()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("writing metadata schema: %w", err)
+	}
+	return sdk.NewResponse().Build(), nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- HTTP method override ---
+
+// methodOverrides recognize middleware and code that let a request choose
+// its effective method through a header or form field.
+var methodOverrides = []struct {
+	mechanism string
+	re        *regexp.Regexp
+}{
+	{"method-override", regexp.MustCompile(`require\(\s*['"]method-override['"]\s*\)|from\s+['"]method-override['"]|\bmethodOverride\s*\(`)},
+	{"HTTPMethodOverrideHandler", regexp.MustCompile(`\bHTTPMethodOverrideHandler\s*\(`)},
+	{"UseHttpMethodOverride", regexp.MustCompile(`\bUseHttpMethodOverride\s*\(`)},
+	{"XHttpMethodOverride", regexp.MustCompile(`\binstall\s*\(\s*XHttpMethodOverride\b`)},
+	{"HiddenHttpMethodFilter", regexp.MustCompile(`\bHiddenHttpMethodFilter\b`)},
+	{"MethodRewriteMiddleware", regexp.MustCompile(`\b(?:MethodRewriteMiddleware|HTTPMethodOverrideMiddleware)\b`)},
+	{"X-HTTP-Method-Override", regexp.MustCompile(`(?i)["']X-HTTP-Method(?:-Override)?["']|["']X-Method-Override["']`)},
+	{"_method", regexp.MustCompile(`\b(?:req\.body|req\.query|request\.(?:form|POST|args|values)|r\.(?:Form|PostForm)Value\s*\()\s*(?:\.|\[|\.get\(|\()?\s*['"]?_method\b`)},
+}
+
+var (
+	// reCSRFProtection matches CSRF middleware, which exempts safe methods
+	// (GET, HEAD, OPTIONS) from token checks.
+	reCSRFProtection = regexp.MustCompile(`\bcsurf\b|\bcsrf\s*\(|\bCSRFProtect\b|\bCsrfViewMiddleware\b|@csrf_protect\b|\bcsrf\.Protect\s*\(|\bnosurf\b|\blusca\.csrf\b|ValidateAntiForgeryToken|\bAddAntiforgery\s*\(|@fastify/csrf`)
+
+	// reMethodCheck matches code that branches on the request method.
+	reMethodCheck = regexp.MustCompile(`\b(?:req|r|request|ctx|context)\.(?:[Mm]ethod)\s*(?:===?|!==?|\bin\b)|\bHttpMethods\.Is\w+\s*\(`)
+)
+
+// overrideRef is a method override or a method-based control.
+type overrideRef struct {
+	kind string
+	file string
+	line int
+}
+
+// methodOverrideTracker collects method overrides and method-based
+// controls per service to find overrides that can bypass the controls.
+type methodOverrideTracker struct {
+	root         string
+	serviceDepth int
+	overrides    []overrideRef
+	controls     map[string][]overrideRef
+}
+
+func newMethodOverrideTracker(root string, serviceDepth int) *methodOverrideTracker {
+	return &methodOverrideTracker{root: root, serviceDepth: serviceDepth, controls: make(map[string][]overrideRef)}
+}
+
+// observe records a method override or method-based control on line.
+// method is the HTTP method of a route registered on the line, if any;
+// a state-changing route guarded by auth middleware is a method-based
+// authorization control. Each override mechanism is recorded once per
+// file, so importing and installing middleware is one override.
+func (t *methodOverrideTracker) observe(line, method, file string, lineNum int) {
+	for _, o := range methodOverrides {
+		if !o.re.MatchString(line) {
+			continue
+		}
+		for _, seen := range t.overrides {
+			if seen.file == file && seen.kind == o.mechanism {
+				return
+			}
+		}
+		t.overrides = append(t.overrides, overrideRef{kind: o.mechanism, file: file, line: lineNum})
+		return
+	}
+
+	kind := ""
+	switch {
+	case reCSRFProtection.MatchString(line):
+		kind = "csrf"
+	case reMethodCheck.MatchString(line):
+		kind = "method check"
+	case (method == "POST" || method == "PUT" || method == "PATCH" || method == "DELETE") && isAuthMiddleware(line):
+		kind = "per-method auth"
+	default:
+		return
+	}
+	service := serviceFor(t.root, file, t.serviceDepth)
+	t.controls[service] = append(t.controls[service], overrideRef{kind: kind, file: file, line: lineNum})
+}
+
+// merge adds what other observed after t's files. Overrides are recorded
+// once per file, so other's never repeat t's.
+func (t *methodOverrideTracker) merge(other *methodOverrideTracker) {
+	t.overrides = append(t.overrides, other.overrides...)
+	for service, refs := range other.controls {
+		t.controls[service] = append(t.controls[service], refs...)
+	}
+}
+
+// report emits ATTACK-082 for every method override in a service that also
+// has method-based controls, listing the controls in metadata.
+func (t *methodOverrideTracker) report(resp *sdk.ResponseBuilder) {
+	for _, o := range t.overrides {
+		controls := t.controls[serviceFor(t.root, o.file, t.serviceDepth)]
+		if len(controls) == 0 {
+			continue
+		}
+		kinds := make(map[string]bool)
+		locations := make([]string, 0, len(controls))
+		for _, c := range controls {
+			kinds[c.kind] = true
+			locations = append(locations, fmt.Sprintf("%s:%d", c.file, c.line))
+		}
+		names := make([]string, 0, len(kinds))
+		for k := range kinds {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		newFinding(
+			resp,
+			"ATTACK-082",
+			fmt.Sprintf("HTTP method override (%s) lets clients bypass method-based controls (%s)", o.kind, strings.Join(names, ", ")),
+		).
+			At(o.file, o.line, o.line).
+			WithMetadata("mechanism", o.kind).
+			WithMetadata("controls", strings.Join(names, ",")).
+			WithMetadata("locations", strings.Join(locations, ",")).
+			Done()
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Explicit security opt-outs ---
+
+// securityOptOut is a decorator, attribute, or call that disables a
+// protection. Global opt-outs are security configuration that applies to
+// the whole application rather than to the handler they appear in.
+type securityOptOut struct {
+	re         *regexp.Regexp
+	protection string
+	global     bool
+}
+
+var securityOptOuts = []securityOptOut{
+	// Django, Flask-WTF, ASP.NET Core antiforgery.
+	{regexp.MustCompile(`@csrf_exempt\b|@csrf\.exempt\b|\[IgnoreAntiforgeryToken\b|\.DisableAntiforgery\(\)`), "csrf", false},
+	// Spring Security HttpSecurity configuration.
+	{regexp.MustCompile(`\.csrf\(\)\s*\.disable\(\)|\bcsrf\s*\(\s*(?:AbstractHttpConfigurer::disable|\w+\s*->\s*\w+\.disable\(\))|\bcsrf\s*\{\s*disable\(\)`), "csrf", true},
+	// ASP.NET Core, Jakarta, Django REST framework.
+	{regexp.MustCompile(`\[AllowAnonymous\b|\.AllowAnonymous\(\)|@AllowAnonymous\b|@PermitAll\b|permission_classes\s*=\s*[\[(]\s*AllowAny\b|@permission_classes\(\s*[\[(]\s*AllowAny\b|authentication_classes\s*=\s*(?:\[\s*\]|\(\s*\))`), "authentication", false},
+	// Spring Security catch-all.
+	{regexp.MustCompile(`\.anyRequest\(\)\s*\.permitAll\(\)`), "authorization", true},
+	// NestJS throttler, ASP.NET Core rate limiting, Flask-Limiter.
+	{regexp.MustCompile(`@SkipThrottle\b|\[DisableRateLimiting\b|\.DisableRateLimiting\(\)|@limiter\.exempt\b`), "rate-limit", false},
+}
+
+// reHandlerDecl captures the name of a function, method, or class
+// declaration that decorators and attributes apply to.
+var reHandlerDecl = regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)|\bfunction\s+(\w+)|\bfun\s+(\w+)|\bfunc\s+(?:\([^)]*\)\s*)?(\w+)|\bclass\s+(\w+)|\bpublic\s+(?:async\s+|static\s+|override\s+|virtual\s+)*[\w<>\[\],.?]+\s+(\w+)\s*\(`)
+
+// optOutWindow is how many lines after an opt-out are searched for the
+// route or handler it decorates.
+const optOutWindow = 6
+
+// securityOptOutAt returns the opt-out on line and the text that matched
+// it, or nil when the line has none.
+func securityOptOutAt(line string) (*securityOptOut, string) {
+	for i, o := range securityOptOuts {
+		if m := o.re.FindString(line); m != "" {
+			return &securityOptOuts[i], strings.Trim(m, "@[().")
+		}
+	}
+	return nil, ""
+}
+
+// optOutTarget returns the route and handler an opt-out on lines[i]
+// applies to. endpoints maps line indexes to the endpoints registered
+// there. Decorators stacked above the opt-out are searched for the route,
+// then the lines below it up to the decorated declaration.
+func optOutTarget(lines []string, i int, endpoints map[int]string) (route, handler string) {
+	if handler = declaredName(lines[i]); endpoints[i] != "" || handler != "" {
+		return endpoints[i], handler
+	}
+	for j := i - 1; j >= 0 && j >= i-optOutWindow && isDecoratorLine(lines[j]); j-- {
+		if endpoints[j] != "" {
+			route = endpoints[j]
+			break
+		}
+	}
+	for j := i + 1; j < len(lines) && j <= i+optOutWindow; j++ {
+		if route == "" {
+			route = endpoints[j]
+		}
+		if handler = declaredName(lines[j]); handler != "" {
+			break
+		}
+	}
+	return route, handler
+}
+
+// declaredName returns the name declared on line, or "".
+func declaredName(line string) string {
+	m := reHandlerDecl.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	for _, g := range m[1:] {
+		if g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+// isDecoratorLine reports whether line is a decorator, annotation, or
+// attribute.
+func isDecoratorLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "@") || strings.HasPrefix(trimmed, "[")
+}
+
+// reportSecurityOptOuts emits ATTACK-071 for every explicit opt-out in
+// lines. Global opt-outs such as Spring's http.csrf().disable(), and
+// opt-outs that decorate no route or handler, have global scope.
+func reportSecurityOptOuts(resp *sdk.ResponseBuilder, filePath string, lines []string, endpoints map[int]string) {
+	for i, line := range lines {
+		optOut, mechanism := securityOptOutAt(line)
+		if optOut == nil {
+			continue
+		}
+		route, handler := "", ""
+		if !optOut.global {
+			route, handler = optOutTarget(lines, i, endpoints)
+		}
+		target, scope := route, "route"
+		switch {
+		case route == "" && handler != "":
+			target, scope = handler, "handler"
+		case route == "":
+			target, scope = "all routes", "global"
+		}
+		f := newFinding(
+			resp,
+			"ATTACK-071",
+			fmt.Sprintf("Explicit %s opt-out for %s (%s)", optOut.protection, target, mechanism),
+		).
+			At(filePath, i+1, i+1).
+			WithMetadata("protection", optOut.protection).
+			WithMetadata("mechanism", mechanism).
+			WithMetadata("scope", scope)
+		if route != "" {
+			f.WithMetadata("endpoint", route)
+		}
+		if handler != "" {
+			f.WithMetadata("handler", handler)
+		}
+		f.Done()
+	}
+}
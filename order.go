@@ -0,0 +1,33 @@
+package main
+
+import (
+	"sort"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Deterministic finding order ---
+
+// sortFindings orders findings by file path, start line, and rule ID, with
+// the message as a final tie-breaker, so that output does not depend on
+// walk or discovery order. Findings ranked by top_n come first, in rank
+// order.
+func sortFindings(out *pluginv1.InvokeToolResponse) {
+	findings := out.GetFindings()
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if ra, rb := findingRank(a), findingRank(b); ra != rb {
+			return ra < rb
+		}
+		if fa, fb := a.GetLocation().GetFilePath(), b.GetLocation().GetFilePath(); fa != fb {
+			return fa < fb
+		}
+		if la, lb := a.GetLocation().GetStartLine(), b.GetLocation().GetStartLine(); la != lb {
+			return la < lb
+		}
+		if a.GetRuleId() != b.GetRuleId() {
+			return a.GetRuleId() < b.GetRuleId()
+		}
+		return a.GetMessage() < b.GetMessage()
+	})
+}
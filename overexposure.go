@@ -0,0 +1,63 @@
+package main
+
+import (
+	"regexp"
+)
+
+// --- Whole-model responses (excessive data exposure) ---
+
+// wholeModelResponses match responses that serialize an object as-is.
+// Group 1 is the serialized variable or model. dump marks calls that dump
+// every attribute (__dict__, model_to_dict) and are reported for any
+// model; bare object responses are reported only for sensitive models.
+var wholeModelResponses = []struct {
+	serializer string
+	dump       bool
+	re         *regexp.Regexp
+}{
+	{"res.json", false, regexp.MustCompile(`\b(?:res|reply|response)\.(?:json|send)\s*\(\s*(?:await\s+)?([A-Za-z_$][\w$]*)\s*\)`)},
+	{"res.json", false, regexp.MustCompile(`\b(?:res|reply|response)\.(?:json|send)\s*\(\s*await\s+([A-Z]\w*)\.find\w*\s*\(`)},
+	{"ctx.body", false, regexp.MustCompile(`\bctx\.body\s*=\s*([A-Za-z_$][\w$]*)\s*;?\s*$`)},
+	{"__dict__", true, regexp.MustCompile(`\b(\w+)\.__dict__\b`)},
+	{"vars", true, regexp.MustCompile(`\b(?:jsonify|JsonResponse|json\.dumps)\s*\(\s*vars\s*\(\s*(\w+)\s*\)`)},
+	{"model_to_dict", true, regexp.MustCompile(`\bmodel_to_dict\s*\(\s*(\w+)\s*\)`)},
+	{"jsonify", false, regexp.MustCompile(`\bjsonify\s*\(\s*(\w+)\s*\)`)},
+	{"c.JSON", false, regexp.MustCompile(`\.(?:JSON|IndentedJSON)\s*\(\s*[\w.]+\s*,\s*&?(\w+)\s*\)`)},
+	{"json.Encoder", false, regexp.MustCompile(`\bjson\.NewEncoder\s*\(\s*\w+\s*\)\.Encode\s*\(\s*&?(\w+)\s*\)`)},
+	{"call.respond", false, regexp.MustCompile(`\bcall\.respond\s*\(\s*(\w+)\s*\)`)},
+	{"Ok", false, regexp.MustCompile(`\breturn\s+(?:Ok|Json)\s*\(\s*(\w+)\s*\)`)},
+}
+
+var (
+	// reSensitiveModelName matches variables and models that typically
+	// hold credentials or personal data: user, currentUser, accounts.
+	reSensitiveModelName = regexp.MustCompile(`(?i)(?:user|account|credential|customer|member|profile|admin|employee|patient)s?$`)
+
+	// reFieldAllowlist matches a serializer, DTO, or explicit field
+	// selection on the response line.
+	reFieldAllowlist = regexp.MustCompile(`(?i)serializ|dto\b|\.pick\(|\bomit\(|\bonly=|\bexclude=|to_?public|sanitiz|\.select\(`)
+
+	// reFileFieldAllowlist matches file-level field control: FastAPI
+	// response models, toJSON overrides, and Go fields hidden from JSON.
+	reFileFieldAllowlist = regexp.MustCompile(`response_model\s*=|\btoJSON\s*\(|json:"-"`)
+)
+
+// wholeModelResponse returns the serialized model and the serializer when
+// line returns an entire model object without a field allowlist, or empty
+// strings.
+func wholeModelResponse(line string) (model, serializer string) {
+	if reFieldAllowlist.MatchString(line) {
+		return "", ""
+	}
+	for _, p := range wholeModelResponses {
+		m := p.re.FindStringSubmatch(line)
+		if len(m) < 2 || m[1] == "self" {
+			continue
+		}
+		if !p.dump && !reSensitiveModelName.MatchString(m[1]) {
+			continue
+		}
+		return m[1], p.serializer
+	}
+	return "", ""
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- List endpoints without pagination ---
+
+// unboundedQueries match queries that return every matching row or
+// document. pattern labels the query shape reported in metadata.
+var unboundedQueries = []struct {
+	pattern string
+	re      *regexp.Regexp
+}{
+	{"SELECT ... FROM", regexp.MustCompile(`(?i)\bSELECT\s+(?:\*|[\w.]+(?:\s*,\s*[\w.]+)*)\s+FROM\s+[\w."` + "`" + `]+`)},
+	{"find().toArray()", regexp.MustCompile(`\.find\([^)]*\)(?:\.\w+\([^)]*\))*\.toArray\(\)`)},
+	{"Model.find()", regexp.MustCompile(`\b[A-Z]\w*\.find\(`)},
+	{"findAll()", regexp.MustCompile(`\.findAll\(`)},
+	{"findMany()", regexp.MustCompile(`\.findMany\(`)},
+	{"objects.all()", regexp.MustCompile(`\.objects\.(?:all|filter)\(`)},
+	{"query.all()", regexp.MustCompile(`\bquery\b.*\.all\(\)`)},
+	{"db.Find()", regexp.MustCompile(`\.Find\(\s*&\w+`)},
+	{"ToList()", regexp.MustCompile(`\.ToList(?:Async)?\(\)`)},
+}
+
+var (
+	// rePagination matches limits, offsets, cursors, and page parameters
+	// anywhere in a handler.
+	rePagination = regexp.MustCompile(`(?i)\blimit\b|\.limit\(|\.take\(|\.skip\(|\boffset\b|paginat|\bpage(?:_?size|_?num(?:ber)?)?\b|per_?page|\bcursor\b|\bTOP\s+\d|\bFETCH\s+(?:FIRST|NEXT)\b|\[\s*\w*\s*:\s*\w+\s*\]|Pageable|PageRequest|\bTake\(|\bSkip\(`)
+
+	// reSingleRowQuery matches queries that return a count or one row.
+	reSingleRowQuery = regexp.MustCompile(`(?i)\bSELECT\s+COUNT\s*\(|\bWHERE\s+[\w.]*\bid\s*=`)
+)
+
+// isCollectionEndpoint reports whether endpoint names a collection rather
+// than a single resource: its last segment is not a path parameter.
+func isCollectionEndpoint(endpoint string) bool {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	last := segments[len(segments)-1]
+	if last == "" {
+		return false
+	}
+	loc := reRouteParam.FindStringIndex(last)
+	return loc == nil || loc[0] != 0 || loc[1] != len(last)
+}
+
+// unboundedListQuery returns the query and its pattern when the handler
+// for the GET route on lines[i] runs an unbounded list query, or empty
+// strings when it paginates or runs no such query.
+func unboundedListQuery(lines []string, defs map[string]int, i int, ext string) (query, pattern string) {
+	body := handlerBody(lines, defs, i, ext)
+	if rePagination.MatchString(lines[i]) || anyLineMatches(body, rePagination) {
+		return "", ""
+	}
+	for _, line := range body {
+		if reSingleRowQuery.MatchString(line) {
+			continue
+		}
+		for _, q := range unboundedQueries {
+			if q.re.MatchString(line) {
+				return strings.TrimSpace(line), q.pattern
+			}
+		}
+	}
+	return "", ""
+}
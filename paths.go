@@ -0,0 +1,52 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Workspace-relative reporting ---
+
+// pathListMetadata are metadata keys holding comma-separated paths (or
+// path:line pairs) that are rewritten along with finding locations.
+var pathListMetadata = []string{"paths", "locations"}
+
+// relativePath returns path relative to root with forward slashes. Paths
+// that are already relative or lie outside root are returned unchanged.
+func relativePath(root, path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// relativizeFindings rewrites finding locations, path-list metadata, and
+// error messages so that they do not embed the workspace's absolute path.
+func relativizeFindings(out *pluginv1.InvokeToolResponse, root string) {
+	prefix := strings.TrimRight(root, string(filepath.Separator)) + string(filepath.Separator)
+	for _, f := range out.GetFindings() {
+		if f.Location != nil {
+			f.Location.FilePath = relativePath(root, f.Location.FilePath)
+		}
+		for _, key := range pathListMetadata {
+			v, ok := f.Metadata[key]
+			if !ok {
+				continue
+			}
+			parts := strings.Split(v, ",")
+			for i, p := range parts {
+				parts[i] = relativePath(root, p)
+			}
+			f.Metadata[key] = strings.Join(parts, ",")
+		}
+		if v, ok := f.Metadata["first_error"]; ok {
+			f.Metadata["first_error"] = strings.ReplaceAll(v, prefix, "")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Hardcoded PEM private keys and certificates ---
+
+// pemExtensions are key and certificate files scanned for ATTACK-076 only.
+var pemExtensions = map[string]bool{
+	".pem": true,
+	".key": true,
+}
+
+var (
+	// rePEMBegin matches a PEM header. Group 1 is the block type, e.g.
+	// "RSA PRIVATE KEY" or "CERTIFICATE".
+	rePEMBegin = regexp.MustCompile(`-----BEGIN ((?:[A-Z0-9]+ )*(?:PRIVATE KEY|CERTIFICATE))-----`)
+
+	// rePEMBody matches base64 key material, including the escaped line
+	// breaks of keys embedded in string literals.
+	rePEMBody = regexp.MustCompile(`[A-Za-z0-9+/=]+`)
+)
+
+// pemBlock is a PEM-encoded key or certificate found in a file.
+type pemBlock struct {
+	blockType string
+	start     int // 0-based index of the BEGIN line
+	end       int // 0-based index of the END line, or start if unterminated
+	body      string
+}
+
+// pemBlockAt returns the PEM block whose header is on lines[i], if any.
+// The body is read until the matching END marker, either later on the same
+// line (escaped "\n" separators) or on the following lines.
+func pemBlockAt(lines []string, i int) (pemBlock, bool) {
+	loc := rePEMBegin.FindStringSubmatchIndex(lines[i])
+	if loc == nil {
+		return pemBlock{}, false
+	}
+	b := pemBlock{blockType: lines[i][loc[2]:loc[3]], start: i, end: i}
+	endMarker := "-----END " + b.blockType + "-----"
+
+	var body strings.Builder
+	rest := lines[i][loc[1]:]
+	for j := i; j < len(lines); j++ {
+		if j > i {
+			rest = lines[j]
+		}
+		done := false
+		if k := strings.Index(rest, endMarker); k >= 0 {
+			rest, done = rest[:k], true
+		}
+		rest = strings.ReplaceAll(rest, `\n`, "")
+		for _, chunk := range rePEMBody.FindAllString(rest, -1) {
+			body.WriteString(chunk)
+		}
+		if done {
+			b.end = j
+			break
+		}
+	}
+	b.body = strings.TrimRight(body.String(), "=")
+	return b, true
+}
+
+// pemKind classifies a block type as a private key or a certificate.
+func pemKind(blockType string) string {
+	if strings.HasSuffix(blockType, "CERTIFICATE") {
+		return "certificate"
+	}
+	return "private key"
+}
+
+// pemExcerpt keeps the first and last few characters of the key material
+// so a finding can be matched to a key without reproducing it.
+func pemExcerpt(body string) string {
+	if len(body) < 16 {
+		return "****"
+	}
+	return body[:4] + "..." + body[len(body)-4:]
+}
+
+// reportPEMBlock emits ATTACK-076 when lines[i] opens a PEM private key or
+// certificate. Empty blocks, such as header constants used for parsing,
+// are skipped.
+func reportPEMBlock(resp *sdk.ResponseBuilder, filePath string, lines []string, i int) {
+	b, ok := pemBlockAt(lines, i)
+	if !ok || len(b.body) < 16 {
+		return
+	}
+	kind := pemKind(b.blockType)
+	excerpt := pemExcerpt(b.body)
+	newFinding(
+		resp,
+		"ATTACK-076",
+		fmt.Sprintf("Hardcoded PEM %s (%s, %s)", kind, b.blockType, excerpt),
+	).
+		At(filePath, b.start+1, b.end+1).
+		WithMetadata("key_type", b.blockType).
+		WithMetadata("kind", kind).
+		WithMetadata("excerpt", excerpt).
+		Done()
+}
+
+// scanPEMFile checks a key or certificate file for ATTACK-076. A returned
+// error means the file could not be read.
+func scanPEMFile(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	for i := range lines {
+		reportPEMBlock(resp, filePath, lines, i)
+	}
+	return nil
+}
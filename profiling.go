@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Programmatic profiling and metrics registration ---
+
+// profilingMechanisms match registrations that expose runtime profiles,
+// variables, or metrics whatever path they are mounted on, in the order
+// they are checked.
+var profilingMechanisms = []struct {
+	name    string
+	label   string
+	pattern *regexp.Regexp
+}{
+	// net/http/pprof registers /debug/pprof on DefaultServeMux when
+	// imported for side effects; the handlers and framework adapters can be
+	// mounted anywhere.
+	{"pprof", "Go profiling (pprof)", regexp.MustCompile(`(?:^\s*|\bimport\s+)_\s+"net/http/pprof"|\bpprof\.(?:Index|Profile|Cmdline|Symbol|Trace|Handler)\b|\bpprof\.(?:Register|RouteRegister|Wrap|New)\s*\(`)},
+	// expvar registers /debug/vars on DefaultServeMux when imported.
+	{"expvar", "Go runtime variables (expvar)", regexp.MustCompile(`(?:^\s*|\bimport\s+)_\s+"expvar"|\bexpvar\.Handler\s*\(`)},
+	{"prometheus", "Prometheus metrics", regexp.MustCompile(`\bpromhttp\.(?:Handler|HandlerFor|InstrumentMetricHandler)\s*\(|\b(?:start_http_server|make_wsgi_app|make_asgi_app)\s*\(|\bdjango_prometheus\.urls\b|\bexpress-prom-bundle\b|\bregister\.metrics\s*\(`)},
+	// Importing inspector is harmless; open starts the debugger listener.
+	{"inspector", "Node inspector", regexp.MustCompile(`\binspector\.open\s*\(`)},
+}
+
+// profilingMechanism returns the mechanism registered on line, its label,
+// and the matched text, or empty strings.
+func profilingMechanism(line string) (mechanism, label, match string) {
+	for _, m := range profilingMechanisms {
+		if loc := m.pattern.FindStringIndex(line); loc != nil {
+			return m.name, m.label, strings.TrimSpace(line[loc[0]:loc[1]])
+		}
+	}
+	return "", "", ""
+}
+
+// reportProfilingExposure emits ATTACK-098 when lines[i] registers a
+// profiling, runtime variable, or metrics handler, raised to High when the
+// file has no auth middleware.
+func reportProfilingExposure(resp *sdk.ResponseBuilder, filePath string, lines []string, i int, hasAuth bool) {
+	mechanism, label, match := profilingMechanism(lines[i])
+	if mechanism == "" {
+		return
+	}
+	rule := rulesByID["ATTACK-098"]
+	severity := rule.Severity
+	qualifier := ""
+	if !hasAuth {
+		severity = sdk.SeverityHigh
+		qualifier = " with no auth middleware in the file"
+	}
+	resp.Finding(
+		rule.ID,
+		severity,
+		rule.Confidence,
+		fmt.Sprintf("%s exposed by %s%s", label, match, qualifier),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("mechanism", mechanism).
+		WithMetadata("registration", match).
+		WithMetadata("auth_in_file", strconv.FormatBool(hasAuth)).
+		Done()
+}
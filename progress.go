@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// --- Scan progress ---
+
+// defaultProgressInterval is how often progress_output is updated when
+// progress_interval is unset.
+const defaultProgressInterval = 2 * time.Second
+
+// scanProgress is one progress_output line.
+type scanProgress struct {
+	FilesScanned int   `json:"files_scanned"`
+	FilesTotal   int   `json:"files_total"`
+	ElapsedMS    int64 `json:"elapsed_ms"`
+	Done         bool  `json:"done"`
+}
+
+// progressReporter appends scan progress to a file as JSON lines while the
+// workspace is walked. The SDK's InvokeTool is unary and cannot stream
+// intermediate messages, so UIs tail the file instead.
+type progressReporter struct {
+	file     *os.File
+	enc      *json.Encoder
+	interval time.Duration
+	start    time.Time
+	last     time.Time
+	scanned  int
+	total    int
+	err      error
+}
+
+// newProgressReporter creates or truncates the progress file at path.
+// total is the estimated number of files the walk visits.
+func newProgressReporter(path string, interval time.Duration, total int) (*progressReporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	now := time.Now()
+	p := &progressReporter{file: file, enc: json.NewEncoder(file), interval: interval, start: now, last: now, total: total}
+	p.write(false)
+	return p, nil
+}
+
+// tick counts one visited file and writes a progress line when the
+// interval has elapsed since the last one.
+func (p *progressReporter) tick() {
+	p.scanned++
+	if p.scanned > p.total {
+		// Files created during the scan.
+		p.total = p.scanned
+	}
+	if now := time.Now(); now.Sub(p.last) >= p.interval {
+		p.last = now
+		p.write(false)
+	}
+}
+
+// finish writes the final line and closes the file. It returns the first
+// error writing progress.
+func (p *progressReporter) finish() error {
+	p.write(true)
+	if err := p.file.Close(); err != nil && p.err == nil {
+		p.err = err
+	}
+	return p.err
+}
+
+// write appends one progress line. After a failed write, later lines are
+// dropped.
+func (p *progressReporter) write(done bool) {
+	if p.err != nil {
+		return
+	}
+	p.err = p.enc.Encode(scanProgress{
+		FilesScanned: p.scanned,
+		FilesTotal:   p.total,
+		ElapsedMS:    time.Since(p.start).Milliseconds(),
+		Done:         done,
+	})
+}
+
+// countWalkFiles returns the number of files the scan walk visits, as the
+// progress estimate. Unreadable directories are left to the scan walk to
+// report.
+func countWalkFiles(ctx context.Context, root string, opts *scanOptions) int {
+	total := 0
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case d.IsDir():
+			if opts.skipDir(root, path, d.Name()) {
+				return filepath.SkipDir
+			}
+		case opts.onlyFiles == nil || opts.onlyFiles[path]:
+			total++
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,107 @@
+package main
+
+import "regexp"
+
+// --- Prototype pollution (JS/TS) ---
+
+// mergeSinks copy nested properties from a source object into a target.
+// Group 1 is the argument list. Deep merges recurse into __proto__ and
+// constructor.prototype; set-by-path writes let the request name the path.
+var mergeSinks = []struct {
+	function string
+	re       *regexp.Regexp
+}{
+	{"_.merge", regexp.MustCompile(`\b(?:_|lodash)\.merge\s*\((.*)`)},
+	{"_.mergeWith", regexp.MustCompile(`\b(?:_|lodash)\.mergeWith\s*\((.*)`)},
+	{"_.defaultsDeep", regexp.MustCompile(`\b(?:_|lodash)\.defaultsDeep\s*\((.*)`)},
+	{"_.set", regexp.MustCompile(`\b(?:_|lodash)\.set(?:With)?\s*\((.*)`)},
+	{"$.extend", regexp.MustCompile(`(?:\$|\bjQuery)\.extend\s*\(\s*true\s*,(.*)`)},
+	{"deepmerge", regexp.MustCompile(`\b(?:deepmerge|deepMerge)(?:\.all)?\s*\((.*)`)},
+	{"deep-extend", regexp.MustCompile(`\bdeepExtend\s*\((.*)`)},
+	{"merge-deep", regexp.MustCompile(`\b(?:mergeDeep|mixinDeep|defaultsDeep)\s*\((.*)`)},
+	{"Hoek.merge", regexp.MustCompile(`\b[Hh]oek\.(?:merge|applyToDefaults)\s*\((.*)`)},
+	{"dot-prop", regexp.MustCompile(`\bdotProp\.set\s*\((.*)`)},
+	{"Object.assign", regexp.MustCompile(`\bObject\.assign\s*\((.*)`)},
+}
+
+var (
+	// reJSFuncDecl matches named JS function declarations and function
+	// expressions assigned to a variable.
+	reJSFuncDecl = regexp.MustCompile(`\bfunction\s+(\w+)\s*\(|\b(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)`)
+
+	// reKeyedCopy matches a computed-key copy from one object to another:
+	// target[key] = source[key].
+	reKeyedCopy = regexp.MustCompile(`\w+\[\s*(\w+)\s*\]\s*=[^=].*\[\s*(\w+)\s*\]`)
+
+	// reForIn matches for...in loops and Object.keys/entries iteration.
+	reForIn = regexp.MustCompile(`\bfor\s*\(\s*(?:const|let|var)?\s*\w+\s+in\b|\bObject\.(?:keys|entries)\s*\(`)
+
+	// rePrototypeGuard matches checks that skip prototype keys or copy into
+	// prototype-less objects.
+	rePrototypeGuard = regexp.MustCompile(`__proto__|['"]constructor['"]|['"]prototype['"]|\bhasOwnProperty\b|\bObject\.hasOwn\s*\(|\bObject\.create\s*\(\s*null\s*\)|\bObject\.freeze\s*\(\s*Object\.prototype\b`)
+
+	// reFreshObjectArg matches an empty object literal as the first argument.
+	reFreshObjectArg = regexp.MustCompile(`^\s*\{\s*\}\s*,`)
+)
+
+// recursiveMergers returns the functions in lines that copy properties by
+// computed key in a for...in loop and call themselves to recurse into
+// nested objects, without guarding against prototype keys. Each name maps
+// to a pattern matching calls to it; group 1 is the argument list.
+func recursiveMergers(lines []string) map[string]*regexp.Regexp {
+	mergers := make(map[string]*regexp.Regexp)
+	for i, line := range lines {
+		m := reJSFuncDecl.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		body := braceBlock(lines, i)
+		if len(body) < 2 || !anyLineMatches(body, reForIn) || !anyLineMatches(body, reKeyedCopy) || anyLineMatches(body, rePrototypeGuard) {
+			continue
+		}
+		call := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\s*\((.*)`)
+		if anyLineMatches(body[1:], call) {
+			mergers[name] = call
+		}
+	}
+	return mergers
+}
+
+// prototypePollution returns the merge function when line merges request
+// input, directly or through a variable in tainted, into an object, or "".
+// Object.assign is shallow and only reported when it mutates an existing
+// object, where a "__proto__" key replaces that object's prototype.
+func prototypePollution(line string, tainted map[string]bool, mergers map[string]*regexp.Regexp) string {
+	if rePrototypeGuard.MatchString(line) {
+		return ""
+	}
+	fromRequest := func(args string) bool {
+		return reRequestInput.MatchString(args) || usesTainted(args, tainted)
+	}
+	for _, sink := range mergeSinks {
+		m := sink.re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if sink.function == "Object.assign" && reFreshObjectArg.MatchString(m[1]) {
+			return ""
+		}
+		if fromRequest(m[1]) {
+			return sink.function
+		}
+		return ""
+	}
+	if reJSFuncDecl.MatchString(line) {
+		return ""
+	}
+	for name, call := range mergers {
+		if m := call.FindStringSubmatch(line); m != nil && fromRequest(m[1]) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// --- Reverse-proxy path rewrites ---
+
+var (
+	// nginx directives.
+	reNginxLocation  = regexp.MustCompile(`^\s*location\s+(?:[=^~*]+\s+)?(/[^\s{]*)\s*\{`)
+	reNginxProxyPass = regexp.MustCompile(`^\s*proxy_pass\s+https?://[^/;\s]+(/[^;\s]*)?\s*;`)
+	reNginxRewrite   = regexp.MustCompile(`^\s*rewrite\s+(\S+)\s+(\S+)`)
+
+	// Kubernetes ingress manifests.
+	reIngressKind          = regexp.MustCompile(`^\s*kind:\s*Ingress\s*$`)
+	reIngressRewriteTarget = regexp.MustCompile(`rewrite-target:\s*["']?([^"'\s]+)`)
+	reIngressPath          = regexp.MustCompile(`^\s*-?\s*path:\s*["']?([^"'\s]+)`)
+)
+
+// proxyRewrite maps an externally exposed path prefix to the internal path
+// prefix the application sees.
+type proxyRewrite struct {
+	external string
+	internal string
+}
+
+// proxyRewrites is the set of rewrites found in checked-in proxy configs.
+type proxyRewrites []proxyRewrite
+
+// externalPath returns the externally reachable path for endpoint, or "" if
+// no rewrite applies. The longest matching internal prefix wins.
+func (r proxyRewrites) externalPath(endpoint string) string {
+	best := -1
+	for i, rw := range r {
+		internal := strings.TrimRight(rw.internal, "/")
+		if endpoint != internal && internal != "" && !strings.HasPrefix(endpoint, internal+"/") {
+			continue
+		}
+		if best < 0 || len(internal) > len(strings.TrimRight(r[best].internal, "/")) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	internal := strings.TrimRight(r[best].internal, "/")
+	rest := strings.TrimPrefix(endpoint, internal)
+	external := strings.TrimRight(r[best].external, "/")
+	if external+rest == "" {
+		return "/"
+	}
+	return external + rest
+}
+
+// collectProxyRewrites walks the workspace for nginx configs and ingress
+// manifests and returns the path rewrites they declare. Parsing is
+// best-effort: unreadable files are recorded in errs and skipped.
+func collectProxyRewrites(ctx context.Context, workspaceRoot string, dirs dirFilter, errs *errorCollector) proxyRewrites {
+	var rewrites proxyRewrites
+	_ = filepath.WalkDir(workspaceRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			errs.add(errKindWalk, path, err)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			if path != workspaceRoot && dirs.skip(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch ext := filepath.Ext(path); {
+		case d.Name() == "nginx.conf" || (ext == ".conf" && strings.Contains(strings.ToLower(path), "nginx")):
+			found, err := parseNginxRewrites(path)
+			errs.add(errKindRead, path, err)
+			rewrites = append(rewrites, found...)
+		case ext == ".yaml" || ext == ".yml":
+			found, err := parseIngressRewrites(path)
+			errs.add(errKindRead, path, err)
+			rewrites = append(rewrites, found...)
+		}
+		return nil
+	})
+	return rewrites
+}
+
+// parseNginxRewrites extracts location/proxy_pass and rewrite mappings.
+func parseNginxRewrites(path string) ([]proxyRewrite, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewrites []proxyRewrite
+	location := ""
+	for _, line := range lines {
+		if m := reNginxLocation.FindStringSubmatch(line); len(m) > 1 {
+			location = m[1]
+			continue
+		}
+		if m := reNginxProxyPass.FindStringSubmatch(line); len(m) > 1 && m[1] != "" && location != "" {
+			rewrites = append(rewrites, proxyRewrite{external: location, internal: m[1]})
+			continue
+		}
+		if m := reNginxRewrite.FindStringSubmatch(line); len(m) > 2 {
+			external := literalPrefix(m[1])
+			internal := literalPrefix(m[2])
+			if strings.HasPrefix(external, "/") && strings.HasPrefix(internal, "/") {
+				rewrites = append(rewrites, proxyRewrite{external: external, internal: internal})
+			}
+		}
+	}
+	return rewrites, nil
+}
+
+// parseIngressRewrites extracts paths from ingress manifests that use the
+// nginx ingress rewrite-target annotation.
+func parseIngressRewrites(path string) ([]proxyRewrite, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	isIngress := false
+	target := ""
+	var paths []string
+	for _, line := range lines {
+		if reIngressKind.MatchString(line) {
+			isIngress = true
+		}
+		if m := reIngressRewriteTarget.FindStringSubmatch(line); len(m) > 1 {
+			target = literalPrefix(m[1])
+			continue
+		}
+		if m := reIngressPath.FindStringSubmatch(line); len(m) > 1 {
+			paths = append(paths, literalPrefix(m[1]))
+		}
+	}
+	if !isIngress || target == "" {
+		return nil, nil
+	}
+
+	rewrites := make([]proxyRewrite, 0, len(paths))
+	for _, p := range paths {
+		if strings.HasPrefix(p, "/") {
+			rewrites = append(rewrites, proxyRewrite{external: p, internal: target})
+		}
+	}
+	return rewrites, nil
+}
+
+// literalPrefix returns the leading literal part of a regex or rewrite
+// replacement, e.g. "^/public/api/(.*)$" -> "/public/api/".
+func literalPrefix(s string) string {
+	s = strings.TrimPrefix(s, "^")
+	if i := strings.IndexAny(s, `.*+?()[]{}|\$`); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// maxPooledReadBuffer caps the buffers readLines returns to its pool, so
+// one huge file does not pin its size for the rest of the scan.
+const maxPooledReadBuffer = 4 << 20
+
+// readBuffers holds file read buffers for reuse across files.
+var readBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// readLines reads a file into memory line by line. Lines are split like
+// bufio.ScanLines but share one string, so a file costs two allocations
+// besides the pooled read buffer. A line of bufio.MaxScanTokenSize bytes
+// or more fails with bufio.ErrTooLong, after the lines before it.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := readBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledReadBuffer {
+			readBuffers.Put(buf)
+		}
+	}()
+	if info, err := f.Stat(); err == nil && info.Size() < math.MaxInt32 {
+		buf.Grow(int(info.Size()) + bytes.MinRead)
+	}
+	if _, err := buf.ReadFrom(f); err != nil {
+		return nil, err
+	}
+
+	text := buf.String()
+	lines := make([]string, 0, strings.Count(text, "\n")+1)
+	for text != "" {
+		line := text
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			line, text = text[:i], text[i+1:]
+		} else {
+			text = ""
+		}
+		if len(line) >= bufio.MaxScanTokenSize {
+			return lines, bufio.ErrTooLong
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+	return lines, nil
+}
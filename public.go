@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// --- Public endpoint classification ---
+
+// publicEndpointPatterns are user-supplied patterns for endpoints that are
+// intentionally public and therefore not reported by ATTACK-002. A "*"
+// matches within one path segment ("/v*/health" matches "/v2/health"), and
+// a final "**" segment matches the prefix and everything below it
+// ("/public/**" matches "/public" and "/public/docs/intro").
+type publicEndpointPatterns []string
+
+// parsePublicEndpoints reads the public_endpoints input and validates each
+// pattern.
+func parsePublicEndpoints(v any) (publicEndpointPatterns, error) {
+	patterns, err := parseStringList(v, "public_endpoints")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "/") {
+			return nil, fmt.Errorf("public_endpoints pattern %q must start with /", p)
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("public_endpoints pattern %q: %w", p, err)
+		}
+	}
+	return patterns, nil
+}
+
+// public reports whether endpoint is commonly public or matches one of the
+// configured patterns.
+func (p publicEndpointPatterns) public(endpoint string) bool {
+	if isCommonPublicEndpoint(endpoint) {
+		return true
+	}
+	for _, pattern := range p {
+		if matchEndpointPattern(pattern, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEndpointPattern matches endpoint against pattern segment by segment,
+// case-insensitively and ignoring trailing slashes.
+func matchEndpointPattern(pattern, endpoint string) bool {
+	patternSegs := strings.Split(strings.Trim(strings.ToLower(pattern), "/"), "/")
+	endpointSegs := strings.Split(strings.Trim(strings.ToLower(endpoint), "/"), "/")
+
+	if last := len(patternSegs) - 1; patternSegs[last] == "**" {
+		patternSegs = patternSegs[:last]
+		if len(endpointSegs) < len(patternSegs) {
+			return false
+		}
+		endpointSegs = endpointSegs[:len(patternSegs)]
+	}
+	if len(patternSegs) != len(endpointSegs) {
+		return false
+	}
+	for i, seg := range patternSegs {
+		if ok, _ := path.Match(seg, endpointSegs[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
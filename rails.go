@@ -0,0 +1,274 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// --- Ruby on Rails routes ---
+
+var (
+	// reRailsVerbRoute matches verb routes: get "/path", post "path" =>
+	// "c#a", and get :search inside resource blocks. Group 1 is the verb
+	// and group 2 or 3 the path or action.
+	reRailsVerbRoute = regexp.MustCompile(`^\s*(get|post|put|patch|delete)\s*\(?\s*(?:['"]([^'"]+)['"]|:(\w+))`)
+
+	// reRailsMatch matches match "path", via: [...]. Group 1 is the path
+	// and group 2 the via list.
+	reRailsMatch = regexp.MustCompile(`^\s*match\s*\(?\s*['"]([^'"]+)['"].*\bvia:\s*(\[[^\]]*\]|%[iw]\[[^\]]*\]|:\w+)`)
+
+	// reRailsRoot matches the root route.
+	reRailsRoot = regexp.MustCompile(`^\s*root\b`)
+
+	// reRailsResources matches resources :users and resource :session.
+	// Group 1 is the helper and group 2 the resource name.
+	reRailsResources = regexp.MustCompile(`^\s*(resources|resource)\s*\(?\s*:(\w+)`)
+
+	// reRailsMount matches mounted Rack apps and engines: mount X => "/x"
+	// and mount X, at: "/x". Group 1 or 2 is the path.
+	reRailsMount = regexp.MustCompile(`^\s*mount\s+.*(?:=>\s*|\bat:\s*)['"]([^'"]+)['"]`)
+
+	// reRailsNamespace and reRailsScope match blocks that prefix the routes
+	// inside them. Group 1 is the prefix; a scope without a path (scope
+	// module: "v1") has none.
+	reRailsNamespace = regexp.MustCompile(`^\s*namespace\s*\(?\s*:(\w+)`)
+	reRailsScope     = regexp.MustCompile(`^\s*scope\b\s*\(?\s*(?:path:\s*)?(?:['"]([^'"]*)['"])?`)
+
+	// reRailsNested matches member and collection blocks in a resource.
+	reRailsNested = regexp.MustCompile(`^\s*(member|collection)\s+do\b`)
+
+	// reRailsOption matches a route option. Group 1 is the name and group
+	// 2 the value: a string, a symbol, or a list.
+	reRailsOption = regexp.MustCompile(`\b(only|except|path|param|on):\s*(['"][^'"]*['"]|\[[^\]]*\]|%[iw]\[[^\]]*\]|:\w+)`)
+
+	// reRubyBlockOpen and reRubyBlockClose track do ... end nesting, along
+	// with the keywords that open an end-terminated statement.
+	reRubyBlockOpen  = regexp.MustCompile(`\bdo\s*(?:\|[^|]*\|)?\s*(?:#.*)?$|^\s*(?:if|unless|case|while|until|begin|def|class|module)\b`)
+	reRubyBlockClose = regexp.MustCompile(`^\s*end\b`)
+
+	// reRailsRoutesDraw matches the routes definition in config/routes.rb.
+	reRailsRoutesDraw = regexp.MustCompile(`\broutes\.draw\b`)
+)
+
+// railsActions lists the RESTful actions resources expands to, with the
+// method and whether the action addresses a member (/users/:id).
+var railsActions = []struct {
+	name   string
+	method string
+	member bool
+}{
+	{"index", "GET", false},
+	{"create", "POST", false},
+	{"show", "GET", true},
+	{"update", "PATCH", true},
+	{"destroy", "DELETE", true},
+}
+
+// matchRailsRoute runs the Rails routing DSL patterns against line.
+// resources and resource report the collection path; railsRouteTracker
+// expands them to their actions.
+func matchRailsRoute(line string) (method, endpoint string) {
+	if m := reRailsResources.FindStringSubmatch(line); m != nil {
+		return "ANY", "/" + railsResourcePath(line, m[2])
+	}
+	if m := reRailsMatch.FindStringSubmatch(line); m != nil {
+		via := railsSymbols(m[2])
+		if len(via) == 1 && via[0] != "all" {
+			return routeMethod(via[0]), "/" + strings.TrimLeft(m[1], "/")
+		}
+		return "ANY", "/" + strings.TrimLeft(m[1], "/")
+	}
+	if m := reRailsVerbRoute.FindStringSubmatch(line); m != nil {
+		return routeMethod(m[1]), "/" + strings.TrimLeft(m[2]+m[3], "/")
+	}
+	if m := reRailsMount.FindStringSubmatch(line); m != nil {
+		return "MOUNT", "/" + strings.TrimLeft(m[1], "/")
+	}
+	if reRailsRoot.MatchString(line) {
+		return "GET", "/"
+	}
+	return "", ""
+}
+
+// railsOption returns the value of a route option on line, unquoted, or
+// "".
+func railsOption(line, name string) string {
+	for _, m := range reRailsOption.FindAllStringSubmatch(line, -1) {
+		if m[1] == name {
+			return strings.Trim(m[2], `'":`)
+		}
+	}
+	return ""
+}
+
+// railsSymbols returns the names in a symbol, string, or list value:
+// :get, [:get, :post], or %i[get post].
+func railsSymbols(value string) []string {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "%i"), "%w")
+	return reIdentifier.FindAllString(value, -1)
+}
+
+// railsResourcePath returns the path segment of a resource: its path:
+// option or its name.
+func railsResourcePath(line, name string) string {
+	if path := railsOption(line, "path"); path != "" {
+		return strings.Trim(path, "/")
+	}
+	return name
+}
+
+// isRailsRoutesFile reports whether a Ruby file holds route definitions:
+// config/routes.rb, the files it draws from config/routes/, or any file
+// calling routes.draw. Controller and spec files use the same verbs (get
+// :show) for other purposes and are not parsed as routes.
+func isRailsRoutesFile(filePath string, lines []string) bool {
+	slashed := filepath.ToSlash(filePath)
+	if strings.HasSuffix(slashed, "config/routes.rb") || strings.Contains(slashed, "config/routes/") {
+		return true
+	}
+	return anyLineMatches(lines, reRailsRoutesDraw)
+}
+
+// extractedRoute is one route registered by a line.
+type extractedRoute struct {
+	method   string
+	endpoint string
+}
+
+// railsRouteTracker follows do ... end nesting through a Rails routes file
+// so that routes inside namespace, scope, and resources blocks get their
+// full path, and expands resources to their RESTful actions.
+type railsRouteTracker struct {
+	// routesFile is set when the file defines routes; other Ruby files
+	// produce none.
+	routesFile bool
+	depth      int
+	frames     []railsFrame
+}
+
+// railsFrame is an open block that prefixes its routes, and the depth it
+// was opened at. member and collection are the paths a resource block's
+// member and collection routes use.
+type railsFrame struct {
+	path       string
+	member     string
+	collection string
+	depth      int
+}
+
+func newRailsRouteTracker(filePath string, lines []string) *railsRouteTracker {
+	return &railsRouteTracker{routesFile: isRailsRoutesFile(filePath, lines)}
+}
+
+// apply returns the routes line registers, with the enclosing prefixes,
+// and then advances the tracker past line. method and endpoint are what
+// extractRoute found on the line.
+func (t *railsRouteTracker) apply(line, method, endpoint string) []extractedRoute {
+	if !t.routesFile {
+		return nil
+	}
+	prefix := ""
+	var top railsFrame
+	if len(t.frames) > 0 {
+		top = t.frames[len(t.frames)-1]
+		prefix = top.path
+	}
+
+	var routes []extractedRoute
+	var frame *railsFrame
+	if m := reRailsResources.FindStringSubmatch(line); m != nil && endpoint != "" {
+		collection := joinRoutePath(prefix, railsResourcePath(line, m[2]))
+		member := collection
+		nested := collection
+		if m[1] == "resources" {
+			param := railsOption(line, "param")
+			if param == "" {
+				param = "id"
+			}
+			member = collection + "/:" + param
+			nested = collection + "/:" + railsSingular(m[2]) + "_" + param
+		}
+		routes = railsResourceRoutes(line, m[1] == "resources", collection, member)
+		frame = &railsFrame{path: nested, member: member, collection: collection}
+	} else if endpoint != "" {
+		base := prefix
+		switch railsOption(line, "on") {
+		case "member":
+			base = top.member
+		case "collection":
+			base = top.collection
+		}
+		full := joinRoutePath(base, endpoint)
+		if full == "" {
+			full = "/"
+		}
+		routes = append(routes, extractedRoute{method: method, endpoint: full})
+	} else if m := reRailsNested.FindStringSubmatch(line); m != nil && len(t.frames) > 0 {
+		path := top.member
+		if m[1] == "collection" {
+			path = top.collection
+		}
+		frame = &railsFrame{path: path, member: top.member, collection: top.collection}
+	} else if m := reRailsNamespace.FindStringSubmatch(line); m != nil {
+		frame = &railsFrame{path: joinRoutePath(prefix, m[1])}
+	} else if m := reRailsScope.FindStringSubmatch(line); m != nil {
+		frame = &railsFrame{path: joinRoutePath(prefix, m[1])}
+	}
+
+	if reRubyBlockOpen.MatchString(line) {
+		if frame == nil {
+			// Other blocks (constraints, authenticate, concern) keep the
+			// enclosing prefix.
+			frame = &railsFrame{path: prefix, member: top.member, collection: top.collection}
+		}
+		frame.depth = t.depth
+		t.frames = append(t.frames, *frame)
+		t.depth++
+	}
+	if reRubyBlockClose.MatchString(line) && t.depth > 0 {
+		t.depth--
+		for len(t.frames) > 0 && t.frames[len(t.frames)-1].depth >= t.depth {
+			t.frames = t.frames[:len(t.frames)-1]
+		}
+	}
+	return routes
+}
+
+// railsResourceRoutes expands a resources (plural) or resource (singular)
+// declaration to the actions its only: and except: options keep. Singular
+// resources have no index action and no :id segment.
+func railsResourceRoutes(line string, plural bool, collection, member string) []extractedRoute {
+	keep := func(string) bool { return true }
+	if only := railsOption(line, "only"); only != "" {
+		names := railsSymbols(only)
+		keep = func(action string) bool { return containsString(names, action) }
+	} else if except := railsOption(line, "except"); except != "" {
+		names := railsSymbols(except)
+		keep = func(action string) bool { return !containsString(names, action) }
+	}
+	var routes []extractedRoute
+	for _, a := range railsActions {
+		if (!plural && a.name == "index") || !keep(a.name) {
+			continue
+		}
+		endpoint := collection
+		if a.member {
+			endpoint = member
+		}
+		routes = append(routes, extractedRoute{method: a.method, endpoint: endpoint})
+	}
+	return routes
+}
+
+// railsSingular returns the singular of a resource name for nested route
+// parameters (users -> user, categories -> category).
+func railsSingular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ses"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	}
+	return strings.TrimSuffix(name, "s")
+}
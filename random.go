@@ -0,0 +1,56 @@
+package main
+
+import (
+	"regexp"
+)
+
+// --- Insecure randomness ---
+
+var (
+	// Non-cryptographic RNG calls, keyed by the source reported in metadata.
+	reGoMathRandCall  = regexp.MustCompile(`\b(?:rand|mrand)\.(?:Int\w*|Uint\w*|Float\w*|Read|Perm|Shuffle|N)\s*\(`)
+	reJSMathRandom    = regexp.MustCompile(`\bMath\.random\s*\(`)
+	rePyRandomCall    = regexp.MustCompile(`\brandom\.(?:random|randint|randrange|choice|choices|sample|getrandbits|uniform)\s*\(`)
+	reJavaUtilRandom  = regexp.MustCompile(`(?:\bnew\s+|[=(]\s*)Random\s*\(|\bThreadLocalRandom\.current\s*\(`)
+	reGoMathRandPkg   = regexp.MustCompile(`"math/rand(?:/v2)?"`)
+	reSecureRandomAPI = regexp.MustCompile(`(?i)(?:SecureRandom|SystemRandom|secrets\.|crypto\.random|crypto/rand|getRandomValues|randomBytes|randomUUID)`)
+
+	// Names of values that must be unpredictable.
+	reSecurityValueName = regexp.MustCompile(`(?i)(?:token|secret|\botp\b|nonce|session|sess_?id|reset_?code|verification_?code|api_?key|csrf|salt)`)
+)
+
+// insecureRandom returns the non-cryptographic RNG source ("math/rand",
+// "Math.random", "random", "java.util.Random", "System.Random") when line uses it to produce
+// a security-sensitive value, or "". goMathRand reports whether a Go file
+// imports math/rand, since rand.X alone may be crypto/rand.
+func insecureRandom(line, ext string, goMathRand bool) string {
+	if !reSecurityValueName.MatchString(line) || reSecureRandomAPI.MatchString(line) {
+		return ""
+	}
+	switch ext {
+	case ".go":
+		if goMathRand && reGoMathRandCall.MatchString(line) {
+			return "math/rand"
+		}
+	case ".py":
+		if rePyRandomCall.MatchString(line) {
+			return "random"
+		}
+	case ".kt":
+		if reJavaUtilRandom.MatchString(line) {
+			return "java.util.Random"
+		}
+		if reJSMathRandom.MatchString(line) {
+			return "Math.random"
+		}
+	case ".cs":
+		if reJavaUtilRandom.MatchString(line) {
+			return "System.Random"
+		}
+	default:
+		if reJSMathRandom.MatchString(line) {
+			return "Math.random"
+		}
+	}
+	return ""
+}
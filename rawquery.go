@@ -0,0 +1,86 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Raw query passthrough ---
+
+// querySinks execute a complete query, command, or search body. Group 1 is
+// the argument holding it. Sinks with a hint only apply to files that
+// mention the datastore, since their method names are generic.
+var querySinks = []struct {
+	datastore string
+	re        *regexp.Regexp
+	hint      *regexp.Regexp
+}{
+	{"sql", regexp.MustCompile(`\.(?:query|raw|execute|executescript|exec|Query|QueryRow|Exec|Raw)\s*\(\s*([^,)]+)`), nil},
+	{"sql", regexp.MustCompile(`\.(?:QueryContext|QueryRowContext|ExecContext)\s*\(\s*[^,]+,\s*([^,)]+)`), nil},
+	{"mongodb", regexp.MustCompile(`\.(?:command|runCommand|aggregate|RunCommand|Aggregate)\s*\(\s*([^,)]+)`), nil},
+	{"elasticsearch", regexp.MustCompile(`\.(?:search|msearch|Search)\s*\(.*?\b(?:body|query)\s*[:=]\s*([^,)}]+)`), regexp.MustCompile(`(?i)elasticsearch|opensearch|_search`)},
+	{"redis", regexp.MustCompile(`\.(?:eval|sendCommand|send_command|execute_command|Do)\s*\(\s*([^,)]+)`), regexp.MustCompile(`(?i)\bredis\b`)},
+	{"neo4j", regexp.MustCompile(`\b(?:session|tx|driver)\.(?:run|Run|executeQuery|execute_query)\s*\(\s*([^,)]+)`), regexp.MustCompile(`(?i)neo4j`)},
+}
+
+// reQueryComposition matches arguments that build a query around input
+// (concatenation, formatting, interpolation) rather than passing it whole.
+// Those are ordinary injection, not a raw query interface.
+var reQueryComposition = regexp.MustCompile("\\+|%|\\$\\{|\\{\\w*\\}|\\bf[\"']|\\.format\\(|Sprintf|\\bString\\.format|[\"'`]")
+
+// wholeRequestNames returns identifiers assigned a request value as-is,
+// e.g. sql = request.json["sql"] or const { query } = req.body.
+func wholeRequestNames(lines []string) map[string]bool {
+	names := make(map[string]bool)
+	for _, line := range lines {
+		if m := reDestructure.FindStringSubmatch(line); len(m) > 2 && isWholeRequestValue(m[2]) {
+			for _, name := range strings.Split(m[1], ",") {
+				name, _, _ = strings.Cut(name, ":")
+				if name = strings.TrimSpace(name); name != "" {
+					names[name] = true
+				}
+			}
+			continue
+		}
+		if m := reAssignment.FindStringSubmatch(line); len(m) > 2 && isWholeRequestValue(m[2]) {
+			names[m[1]] = true
+		}
+	}
+	return names
+}
+
+// isWholeRequestValue reports whether expr reads request input without
+// composing it into a larger string. Quoted keys such as
+// request.json["sql"] or r.FormValue("q") are allowed.
+func isWholeRequestValue(expr string) bool {
+	expr = strings.TrimSuffix(strings.TrimSpace(expr), ";")
+	if !reRequestInput.MatchString(expr) {
+		return false
+	}
+	return !reQueryComposition.MatchString(reQuotedKey.ReplaceAllString(expr, ""))
+}
+
+// reQuotedKey matches quoted subscripts and call arguments: ["sql"], ('q').
+// The closing bracket is optional because sink arguments are captured up to
+// the first ")".
+var reQuotedKey = regexp.MustCompile(`[\[(]\s*["'][^"']*["']\s*[\])]?`)
+
+// rawQuerySink returns the datastore when line executes a query taken
+// wholesale from request input, directly or through a name in whole, or "".
+// lines is the whole file, searched for datastore hints.
+func rawQuerySink(line string, lines []string, whole map[string]bool) string {
+	for _, sink := range querySinks {
+		m := sink.re.FindStringSubmatch(line)
+		if len(m) < 2 {
+			continue
+		}
+		if sink.hint != nil && !anyLineMatches(lines, sink.hint) {
+			continue
+		}
+		arg := strings.TrimSpace(m[1])
+		if whole[arg] || isWholeRequestValue(arg) {
+			return sink.datastore
+		}
+	}
+	return ""
+}
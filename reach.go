@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Route reachability ---
+
+// reachabilityLookback bounds how far back functionStarts looks for the
+// function a line belongs to.
+const reachabilityLookback = 300
+
+// functionDefinitions match named function definitions per language.
+// Group 1 is the name.
+var functionDefinitions = map[string]*regexp.Regexp{
+	".go": regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?(\w+)\s*[\[(]`),
+	".py": regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)\s*\(`),
+	".js": regexp.MustCompile(`^\s*(?:export\s+)?(?:async\s+)?function\s*\*?\s*(\w+)\s*\(|^\s*(?:export\s+)?(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)`),
+}
+
+// entryPoints are functions the runtime calls without a reference in
+// source.
+var entryPoints = map[string]bool{
+	"main":     true,
+	"init":     true,
+	"__init__": true,
+}
+
+var (
+	// reBuildConstraint matches a Go build constraint line. Group 1 is the
+	// expression.
+	reBuildConstraint = regexp.MustCompile(`^//\s*(?:go:build|\+build)\s+(.+)$`)
+
+	// reBuildTag matches the tags of a build constraint expression.
+	reBuildTag = regexp.MustCompile(`[\w.]+`)
+
+	// reReleaseTag matches Go release tags such as go1.21.
+	reReleaseTag = regexp.MustCompile(`^go1\.\d+$`)
+)
+
+// platformTags are build tags that select a platform or toolchain rather
+// than exclude code from production builds.
+var platformTags = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"illumos": true, "ios": true, "js": true, "linux": true, "netbsd": true,
+	"openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true,
+	"unix": true, "386": true, "amd64": true, "arm": true, "arm64": true,
+	"loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+	"ppc64": true, "ppc64le": true, "riscv64": true, "s390x": true, "wasm": true,
+	"cgo": true, "gc": true, "gccgo": true,
+}
+
+// functionLanguage returns the key of functionDefinitions for ext, or "".
+func functionLanguage(ext string) string {
+	if jsExtensions[ext] {
+		return ".js"
+	}
+	if functionDefinitions[ext] != nil {
+		return ext
+	}
+	return ""
+}
+
+// definedFunction returns the name of the function defined on line, or "".
+func definedFunction(line, lang string) string {
+	re := functionDefinitions[lang]
+	if re == nil {
+		return ""
+	}
+	m := re.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	for _, g := range m[1:] {
+		if g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+// enclosingFunction returns the name of the innermost named function whose
+// body contains lines[i], or "" at the top level. starts is the file's
+// functionStarts.
+func enclosingFunction(lines []string, starts []int, i int, ext string) string {
+	if j := starts[i]; j >= 0 {
+		return definedFunction(lines[j], functionLanguage(ext))
+	}
+	return ""
+}
+
+// functionStarts returns, for every line, the index of the definition line
+// of the innermost named function whose body contains it, or -1 at the top
+// level. Bodies are bounded by indentation in Python and by braceBlock
+// elsewhere; definitions more than reachabilityLookback lines up are not
+// considered. It is computed once per file and indexed by line.
+func functionStarts(lines []string, ext string) []int {
+	starts := make([]int, len(lines))
+	for i := range starts {
+		starts[i] = -1
+	}
+	lang := functionLanguage(ext)
+	if lang == ".py" {
+		indentFunctionStarts(lines, starts)
+	} else if lang != "" {
+		braceFunctionStarts(lines, lang, starts)
+	}
+	return starts
+}
+
+// indentFunctionStarts fills starts for Python, where the enclosing
+// function is the nearest def among the less indented lines above.
+func indentFunctionStarts(lines []string, starts []int) {
+	type scope struct {
+		line, indent int
+		def          bool
+	}
+	var stack []scope
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		for k := len(stack) - 1; k >= 0 && i-stack[k].line <= reachabilityLookback; k-- {
+			if stack[k].def {
+				starts[i] = stack[k].line
+				break
+			}
+		}
+		stack = append(stack, scope{line: i, indent: indent, def: definedFunction(line, ".py") != ""})
+	}
+}
+
+// braceFunctionStarts fills starts for brace-delimited languages. Each
+// definition's body is the first brace block opened at or after it, as in
+// braceBlock, and stays open until that block closes.
+func braceFunctionStarts(lines []string, lang string, starts []int) {
+	type body struct {
+		line, base int
+		opened     bool
+	}
+	var open []body
+	depth := 0
+	for i, line := range lines {
+		for len(open) > 0 && i-open[0].line > reachabilityLookback {
+			open = open[1:]
+		}
+		if n := len(open); n > 0 {
+			starts[i] = open[n-1].line
+		}
+		if definedFunction(line, lang) != "" {
+			open = append(open, body{line: i, base: depth})
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		kept := open[:0]
+		for _, b := range open {
+			b.opened = b.opened || depth > b.base
+			if !b.opened || depth > b.base {
+				kept = append(kept, b)
+			}
+		}
+		open = kept
+	}
+}
+
+// buildConstraint returns the expression of a Go file's build constraint
+// when it names a tag other than a platform, toolchain, or release tag.
+func buildConstraint(lines []string) string {
+	for _, line := range lines {
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		m := reBuildConstraint.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		for _, tag := range reBuildTag.FindAllString(m[1], -1) {
+			if !platformTags[tag] && !reReleaseTag.MatchString(tag) {
+				return strings.TrimSpace(m[1])
+			}
+		}
+	}
+	return ""
+}
+
+// registrationSite is where a route is registered, and the context that
+// may make it unreachable.
+type registrationSite struct {
+	file, function, constraint string
+	line                       int
+}
+
+// reachabilityTracker counts function definitions and references across
+// non-test source files, and annotates routes registered in functions no
+// code refers to, or in files behind a build constraint.
+type reachabilityTracker struct {
+	definitions map[string]int
+	references  map[string]int
+	routes      []registrationSite
+}
+
+func newReachabilityTracker() *reachabilityTracker {
+	return &reachabilityTracker{definitions: make(map[string]int), references: make(map[string]int)}
+}
+
+// collect counts the function definitions and identifier occurrences of a
+// source file.
+func (t *reachabilityTracker) collect(lines []string, ext string) {
+	lang := functionLanguage(ext)
+	for _, line := range lines {
+		if name := definedFunction(line, lang); name != "" {
+			t.definitions[name]++
+		}
+		for _, id := range reIdentifier.FindAllString(line, -1) {
+			t.references[id]++
+		}
+	}
+}
+
+// record notes a route registered on lines[i]. starts is the file's
+// functionStarts.
+func (t *reachabilityTracker) record(filePath string, lines []string, starts []int, i int, ext, constraint string) {
+	t.routes = append(t.routes, registrationSite{
+		file:       filePath,
+		line:       i + 1,
+		function:   enclosingFunction(lines, starts, i, ext),
+		constraint: constraint,
+	})
+}
+
+// merge adds the counts and routes other recorded after t's.
+func (t *reachabilityTracker) merge(other *reachabilityTracker) {
+	for name, n := range other.definitions {
+		t.definitions[name] += n
+	}
+	for id, n := range other.references {
+		t.references[id] += n
+	}
+	t.routes = append(t.routes, other.routes...)
+}
+
+// reason returns why the route at site may be unreachable, or "".
+func (t *reachabilityTracker) reason(site registrationSite) string {
+	if site.constraint != "" {
+		return "build constraint: " + site.constraint
+	}
+	if site.function != "" && !entryPoints[site.function] && t.references[site.function] <= t.definitions[site.function] {
+		return fmt.Sprintf("registered in %s, which has no caller", site.function)
+	}
+	return ""
+}
+
+// declaresReachability reports whether a rule's metadata contract includes
+// the reachability fields.
+func declaresReachability(ruleID string) bool {
+	for _, f := range ruleMetadata[ruleID] {
+		if f.Key == "reachability" {
+			return true
+		}
+	}
+	return false
+}
+
+// tag sets reachability metadata on the endpoint findings of routes that
+// may be unreachable. It runs before paths are relativized.
+func (t *reachabilityTracker) tag(out *pluginv1.InvokeToolResponse) {
+	reasons := make(map[string]string)
+	for _, site := range t.routes {
+		if r := t.reason(site); r != "" {
+			reasons[fmt.Sprintf("%s:%d", site.file, site.line)] = r
+		}
+	}
+	if len(reasons) == 0 {
+		return
+	}
+	for _, f := range out.GetFindings() {
+		if f.GetMetadata()["endpoint"] == "" || !declaresReachability(f.GetRuleId()) {
+			continue
+		}
+		if r, ok := reasons[fmt.Sprintf("%s:%d", f.GetLocation().GetFilePath(), f.GetLocation().GetStartLine())]; ok {
+			f.Metadata["reachability"] = "uncertain"
+			f.Metadata["reachability_reason"] = r
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Regular expression denial of service ---
+
+var (
+	// reRegexLiteral matches regex literals in backtracking engines: JS
+	// /.../ literals before a match call or assigned, new RegExp, Python
+	// re calls, Java Pattern.compile and String.matches, and PHP
+	// preg_* functions. Go's regexp is linear-time and is not matched. One
+	// of the groups holds the pattern.
+	reRegexLiteral = regexp.MustCompile(`(?:^|[=(,:!&|?]\s*)/((?:[^/\\\n]|\\.)+)/[dgimsuy]*\s*(?:\.(?:test|exec)\(|[;,)]|$)|\bnew RegExp\(\s*['"]((?:[^'"\\]|\\.)+)['"]|\bre\.(?:match|search|fullmatch|compile|findall|finditer|sub|split)\(\s*r?['"]((?:[^'"\\]|\\.)+)['"]|\bPattern\.(?:compile|matches)\(\s*"((?:[^"\\]|\\.)+)"|\.matches\(\s*"((?:[^"\\]|\\.)+)"\s*\)|\bpreg_\w+\(\s*'/((?:[^/'\\]|\\.)+)/`)
+
+	// reRegexAssignment matches a regex bound to a name. Group 1 is the
+	// name.
+	reRegexAssignment = regexp.MustCompile(`^\s*(?:(?:export\s+)?(?:const|let|var|final|static|private|public)\s+)*(?:\w+\s+)?(\w+)\s*=`)
+
+	// reRegexValidator matches validators that apply a regex to request
+	// fields: class-validator @Matches, express-validator .matches(),
+	// pydantic Field/constr patterns, Bean Validation @Pattern, and
+	// ASP.NET [RegularExpression].
+	reRegexValidator = regexp.MustCompile(`@Matches\(|\.matches\(\s*/|\bField\(.*\b(?:regex|pattern)\s*=|\bconstr\(.*\b(?:regex|pattern)\s*=|@Pattern\(|\[RegularExpression\(`)
+)
+
+// regexLiteral returns the first regex pattern written on line, or "".
+func regexLiteral(line string) string {
+	m := reRegexLiteral.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	for _, g := range m[1:] {
+		if g != "" {
+			return g
+		}
+	}
+	return ""
+}
+
+// redosConstruct returns the catastrophic-backtracking construct in
+// pattern: "nested_quantifier" for a repeated group whose body repeats,
+// as in (a+)+, or "overlapping_alternation" for a repeated group whose
+// alternatives overlap, as in (a|aa)*. It returns "" for safe patterns.
+func redosConstruct(pattern string) string {
+	type group struct {
+		start      int
+		quantified bool
+	}
+	var stack []group
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\':
+			i++
+		case inClass:
+			inClass = c != ']'
+		case c == '[':
+			inClass = true
+		case c == '(':
+			stack = append(stack, group{start: i})
+		case c == ')' && len(stack) > 0:
+			g := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			repeated := unboundedQuantifier(pattern[i+1:])
+			if repeated && g.quantified {
+				return "nested_quantifier"
+			}
+			if repeated && overlappingAlternatives(pattern[g.start+1:i]) {
+				return "overlapping_alternation"
+			}
+			if len(stack) > 0 && (g.quantified || repeated) {
+				stack[len(stack)-1].quantified = true
+			}
+		case (c == '+' || c == '*' || c == '{') && len(stack) > 0 && unboundedQuantifier(pattern[i:]):
+			stack[len(stack)-1].quantified = true
+		}
+	}
+	return ""
+}
+
+// unboundedQuantifier reports whether s starts with +, *, or {n,}.
+func unboundedQuantifier(s string) bool {
+	switch {
+	case strings.HasPrefix(s, "+"), strings.HasPrefix(s, "*"):
+		return true
+	case strings.HasPrefix(s, "{"):
+		end := strings.IndexByte(s, '}')
+		return end > 0 && strings.HasSuffix(s[:end], ",")
+	}
+	return false
+}
+
+// overlappingAlternatives reports whether two top-level alternatives of a
+// group body can match the same text: one equals or prefixes another.
+func overlappingAlternatives(body string) bool {
+	body = strings.TrimPrefix(strings.TrimPrefix(body, "?:"), "?")
+	var alts []string
+	depth, last := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				alts = append(alts, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	alts = append(alts, body[last:])
+	for i, a := range alts {
+		for j, b := range alts {
+			if i != j && a != "" && strings.HasPrefix(b, a) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redosInputRegexes returns the names bound to regexes on lines that are
+// applied to request input elsewhere in the file.
+func redosInputRegexes(lines []string) map[string]bool {
+	names := make(map[string]bool)
+	for _, line := range lines {
+		if regexLiteral(line) == "" {
+			continue
+		}
+		if m := reRegexAssignment.FindStringSubmatch(line); m != nil {
+			names[m[1]] = false
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	for _, line := range lines {
+		if !reRequestInput.MatchString(line) {
+			continue
+		}
+		for _, id := range reIdentifier.FindAllString(line, -1) {
+			if _, ok := names[id]; ok {
+				names[id] = true
+			}
+		}
+	}
+	return names
+}
+
+// reportReDoS emits ATTACK-100 when lines[i] writes a regex with
+// catastrophic backtracking that is applied to request input: on the
+// same line, as a field validator, or through the name it is assigned to.
+func reportReDoS(resp *sdk.ResponseBuilder, filePath string, lines []string, i int, inputRegexes map[string]bool) {
+	line := lines[i]
+	pattern := regexLiteral(line)
+	if pattern == "" {
+		return
+	}
+	construct := redosConstruct(pattern)
+	if construct == "" {
+		return
+	}
+	applied := ""
+	switch {
+	case reRegexValidator.MatchString(line):
+		applied = "validator"
+	case reRequestInput.MatchString(line):
+		applied = "request_input"
+	default:
+		if m := reRegexAssignment.FindStringSubmatch(line); m != nil && inputRegexes[m[1]] {
+			applied = "request_input"
+		}
+	}
+	if applied == "" {
+		return
+	}
+	newFinding(
+		resp,
+		"ATTACK-100",
+		fmt.Sprintf("Regular expression with catastrophic backtracking (%s) applied to request input: %s", strings.ReplaceAll(construct, "_", " "), pattern),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("pattern", pattern).
+		WithMetadata("construct", construct).
+		WithMetadata("applied_to", applied).
+		Done()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Request parameters reflected into responses ---
+
+var (
+	// reReflectedParam matches a named request parameter. One of the
+	// groups holds the parameter name.
+	reReflectedParam = regexp.MustCompile(`\b(?:req|request|ctx)\.(?:query|params|body|args|form|GET|POST|values)(?:\.get(?:list)?\(\s*['"]([\w-]+)['"]|\[\s*['"]([\w-]+)['"]\s*\]|\.(\w+))|\b\w+\.URL\.Query\(\)\.Get\(\s*"([\w-]+)"|\b\w+\.(?:FormValue|PostFormValue|PathValue)\(\s*"([\w-]+)"|\bc\.(?:Query|DefaultQuery|Param|PostForm|QueryParam|FormValue)\(\s*"([\w-]+)"`)
+
+	// reResponseSink matches calls that write their argument into the
+	// response body. Group 1 or 2 is the function.
+	reResponseSink = regexp.MustCompile(`\b(res\.(?:send|write|end|json|jsonp)|jsonify|JsonResponse|HttpResponse|make_response|Response|w\.Write|c\.(?:String|JSON|IndentedJSON|JSONP|HTML|Data|Blob|XML))\s*\(|\b(fmt\.Fprint\w*|io\.WriteString)\(\s*w\s*,`)
+
+	// reReturnSink matches a Python view returning a value as the body.
+	reReturnSink = regexp.MustCompile(`^\s*return\s+`)
+
+	// reOutputEncoding matches escaping and sanitizing of reflected values.
+	reOutputEncoding = regexp.MustCompile(`(?i)escape|encode|sanitiz|bleach|DOMPurify|markupsafe|\bquote\(|strconv\.Quote`)
+
+	// reResponseContentType matches a Content-Type set for the response.
+	// One of the groups holds the type.
+	reResponseContentType = regexp.MustCompile(`(?i)content[-_]?type['"]?\s*[,:=]\s*['"]([^'"]+)['"]|\bmimetype\s*=\s*['"]([^'"]+)['"]|\bres\.(?:type|contentType)\(\s*['"]([^'"]+)['"]`)
+)
+
+// jsonSinks write their argument as JSON.
+var jsonSinks = map[string]bool{
+	"res.json":       true,
+	"res.jsonp":      true,
+	"jsonify":        true,
+	"JsonResponse":   true,
+	"c.JSON":         true,
+	"c.IndentedJSON": true,
+	"c.JSONP":        true,
+}
+
+// reflectedParameter returns the request parameter on line that is written
+// into the response without encoding, with the sink writing it, or empty
+// strings. A bare return is a sink when returns is set; the caller checks
+// that the line is in a route handler.
+func reflectedParameter(line string, returns bool) (param, sink string) {
+	arg := ""
+	if m := reResponseSink.FindStringSubmatchIndex(line); m != nil {
+		if m[2] >= 0 {
+			sink = line[m[2]:m[3]]
+		} else {
+			sink = line[m[4]:m[5]]
+		}
+		arg = line[m[1]:]
+	} else if returns {
+		if loc := reReturnSink.FindStringIndex(line); loc != nil {
+			sink, arg = "return", line[loc[1]:]
+		}
+	}
+	if arg == "" || reOutputEncoding.MatchString(arg) {
+		return "", ""
+	}
+	m := reReflectedParam.FindStringSubmatch(arg)
+	if m == nil {
+		return "", ""
+	}
+	for _, g := range m[1:] {
+		if g != "" {
+			return g, sink
+		}
+	}
+	return "", ""
+}
+
+// responseType classifies the body a sink writes: "json" for JSON
+// helpers, else by the Content-Type set in the handler before it, with
+// strings defaulting to "html" (or "text" for Gin's c.String).
+func responseType(sink string, handler []string) string {
+	switch {
+	case jsonSinks[sink]:
+		return "json"
+	case sink == "c.HTML":
+		return "html"
+	}
+	contentType := ""
+	for _, line := range handler {
+		if m := reResponseContentType.FindStringSubmatch(line); m != nil {
+			contentType = strings.ToLower(m[1] + m[2] + m[3])
+		}
+	}
+	switch {
+	case strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "html"):
+		return "html"
+	case contentType != "", sink == "c.String":
+		return "text"
+	}
+	return "html"
+}
+
+// reflectionHandler returns the lines of the function containing lines[i]
+// up to it, or the few lines before it at the top level or in an anonymous
+// handler, and whether that function is a decorated Python route view.
+func reflectionHandler(lines []string, starts []int, i int, ext string, endpointsByLine map[int]string) ([]string, bool) {
+	start := starts[i]
+	if start < 0 {
+		return lines[max(0, i-optOutWindow) : i+1], false
+	}
+	route := false
+	for k := start - 1; ext == ".py" && k >= 0 && strings.HasPrefix(strings.TrimSpace(lines[k]), "@"); k-- {
+		route = route || endpointsByLine[k] != ""
+	}
+	return lines[start : i+1], route
+}
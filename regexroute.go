@@ -0,0 +1,100 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Regex route patterns ---
+
+var (
+	// Tornado application routes: (r"/user/([0-9]+)", UserHandler).
+	rePyTornado = regexp.MustCompile(`^\s*\(\s*r?["'](/[^"']*)["']\s*,\s*[\w.]+Handler\b`)
+
+	// Express/Koa routes registered with a regex literal instead of a string.
+	reJSRegexRoute = regexp.MustCompile(`(?:app|router)\.\s*(get|post|put|delete|patch|all|use)\s*\(\s*/((?:[^/\\\s]|\\.)+)/[a-z]*\s*[,)]`)
+
+	// Regex syntax that does not occur in literal or parameterized paths:
+	// anchors, escapes, groups, quantified character classes, and wildcards.
+	reRegexRouteSyntax = regexp.MustCompile(`^\^|\$$|\\|\(|\[[^\]]*\][*+?{]|\.[*+]`)
+
+	// Regex atoms that match one path segment's worth of characters.
+	reRegexParamAtom = regexp.MustCompile(`\\[dwsDWS][*+]?|\[[^\]]*\](?:[*+?]|\{\d*,?\d*\})?|\.[*+]`)
+)
+
+// isRegexRoute reports whether an extracted endpoint is a regular
+// expression (Django re_path/url, Tornado, Express regex routes) rather
+// than a literal or parameterized path.
+func isRegexRoute(endpoint string) bool {
+	return reRegexRouteSyntax.MatchString(endpoint)
+}
+
+// simplifyRegexRoute derives a readable path from a regex route: anchors
+// are stripped, named groups become {name}, other groups, alternations,
+// and character classes become {param}, non-capturing groups without
+// alternatives keep their content, and escaped characters are unescaped.
+// ^articles/(?P<year>[0-9]{4})/$ becomes /articles/{year}/.
+func simplifyRegexRoute(pattern string) string {
+	p := strings.TrimPrefix(pattern, "^")
+	p = strings.TrimSuffix(p, "$")
+	p = simplifyRegexGroups(p)
+	p = reRegexParamAtom.ReplaceAllString(p, "{param}")
+	p = strings.NewReplacer(`\/`, "/", `\.`, ".", `\-`, "-", `\_`, "_").Replace(p)
+	p = strings.NewReplacer("?", "", "+", "", "*", "").Replace(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
+
+// simplifyRegexGroups rewrites the parenthesized groups of p, skipping any
+// quantifier that follows a group.
+func simplifyRegexGroups(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case c == '\\' && i+1 < len(p):
+			b.WriteString(p[i : i+2])
+			i++
+		case c == '(':
+			end := closingParen(p, i)
+			inner := p[i+1 : end]
+			switch {
+			case strings.HasPrefix(inner, "?P<") || strings.HasPrefix(inner, "?<"):
+				name, _, _ := strings.Cut(inner[strings.Index(inner, "<")+1:], ">")
+				b.WriteString("{" + name + "}")
+			case strings.HasPrefix(inner, "?:") && !strings.Contains(inner, "|"):
+				b.WriteString(simplifyRegexGroups(inner[2:]))
+			default:
+				b.WriteString("{param}")
+			}
+			i = end
+			for i+1 < len(p) && strings.IndexByte("?*+", p[i+1]) >= 0 {
+				i++
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// closingParen returns the index of the parenthesis closing the group that
+// opens at p[open], or the last index when the group is unterminated.
+func closingParen(p string, open int) int {
+	depth := 0
+	for i := open; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(p) - 1
+}
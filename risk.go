@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Numeric risk scores ---
+
+// severityRiskScores are the default 0-10 scores assigned by severity when
+// a rule has no explicit entry in the score table.
+var severityRiskScores = map[pluginv1.Severity]float64{
+	sdk.SeverityCritical: 9.5,
+	sdk.SeverityHigh:     7.5,
+	sdk.SeverityMedium:   5.0,
+	sdk.SeverityLow:      2.5,
+	sdk.SeverityInfo:     0.0,
+}
+
+// parseRiskScores reads the risk_scores input. It accepts true to enable
+// scoring with the defaults, or an object mapping rule IDs to scores to
+// enable scoring with per-rule overrides. The returned map is nil when
+// scoring is disabled.
+func parseRiskScores(v any) (map[string]float64, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		if !t {
+			return nil, nil
+		}
+		return map[string]float64{}, nil
+	case map[string]any:
+		scores := make(map[string]float64, len(t))
+		for rule, raw := range t {
+			score, ok := raw.(float64)
+			if !ok || score < 0 || score > 10 {
+				return nil, fmt.Errorf("risk score for %s must be a number between 0 and 10", rule)
+			}
+			scores[rule] = score
+		}
+		return scores, nil
+	default:
+		return nil, fmt.Errorf("risk_scores must be a boolean or an object, got %T", v)
+	}
+}
+
+// applyRiskScores attaches a risk_score metadata field to every finding,
+// using the rule's override if present and the severity default otherwise.
+func applyRiskScores(out *pluginv1.InvokeToolResponse, overrides map[string]float64) {
+	for _, f := range out.GetFindings() {
+		score, ok := overrides[f.GetRuleId()]
+		if !ok {
+			score = severityRiskScores[f.GetSeverity()]
+		}
+		if f.Metadata == nil {
+			f.Metadata = map[string]string{}
+		}
+		f.Metadata["risk_score"] = strconv.FormatFloat(score, 'f', 1, 64)
+	}
+}
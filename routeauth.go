@@ -0,0 +1,258 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Per-route authentication ---
+
+// routeAuthWindow is how many lines before and after a route declaration
+// are searched for auth middleware chained on it.
+const routeAuthWindow = 3
+
+var (
+	// reAuthUse matches use()/Use() middleware registrations. Group 1 is
+	// the receiver and group 2 the path the middleware is mounted on, if
+	// any.
+	reAuthUse = regexp.MustCompile(`\b(\w+)\s*\.\s*(?:use|Use)\s*\(\s*(?:["'](/[^"']*)["'])?`)
+
+	// reGlobalAuthHook matches FastAPI add_middleware and Flask
+	// before_request hooks, which run for every route of the app.
+	reGlobalAuthHook = regexp.MustCompile(`\.(?:add_middleware|before_request|before_app_request)\s*\(`)
+
+	// reRouterGroup matches a router group assigned to a variable (Gin,
+	// Echo, Fiber Group). Group 1 is the variable and group 2 the router
+	// it is derived from.
+	reRouterGroup = regexp.MustCompile(`^\s*(\w+)\s*:?=\s*(?:[\w.]*\.)?(\w+)\s*\.\s*Group\s*\(`)
+
+	// reRouterClosure matches a chi Route or Group that registers its
+	// routes in a closure, as in r.Route("/admin", func(r chi.Router) {.
+	reRouterClosure = regexp.MustCompile(`\.\s*(?:Route|Group)\s*\(.*\bfunc\s*\(\s*\w+\s+[\w.*]+\s*\)\s*\{\s*$`)
+
+	// reAuthRouter matches a FastAPI app or APIRouter assigned to a
+	// variable with router-level dependencies. Group 1 is the variable.
+	reAuthRouter = regexp.MustCompile(`^\s*(\w+)\s*=\s*(?:FastAPI|APIRouter)\s*\(.*\bdependencies\s*=`)
+
+	// reAuthBlock matches Ktor authenticate { } and Rails authenticate ...
+	// do blocks, whose routes are protected.
+	reAuthBlock = regexp.MustCompile(`^\s*authenticated?\b.*(?:\{|\bdo)\s*(?:\|[^|]*\|)?\s*$`)
+
+	// reAuthSetup matches passport middleware that loads the session user
+	// without requiring one.
+	reAuthSetup = regexp.MustCompile(`passport\s*\.\s*(?:initialize|session)\s*\(\s*\)`)
+
+	// reRouteReceiver matches the router a route is registered on, as in
+	// admin.POST(...) or @router.get(...). Group 1 is the receiver.
+	reRouteReceiver = regexp.MustCompile(`^\s*@?(\w+)\s*\.`)
+)
+
+// fileAuth is the auth middleware a file registers for more than a single
+// route: for every route, for the routes of a router group, for the routes
+// under a path prefix, or for the routes inside an authenticate block or
+// router closure.
+type fileAuth struct {
+	// global covers every route in the file: path-less use()/Use() on an
+	// app or router, app-wide hooks, and class-level auth decorations.
+	global bool
+	// groups are router variables whose routes are covered, along with
+	// those of the groups derived from them.
+	groups map[string]bool
+	// parents maps each router group variable to the router it is derived
+	// from.
+	parents map[string]string
+	// prefixes are paths auth middleware is mounted on.
+	prefixes []string
+	// blocks marks the lines inside authenticate blocks and inside router
+	// closures that Use auth middleware.
+	blocks []bool
+}
+
+// scanFileAuth collects the auth middleware lines register beyond single
+// routes. use()/Use() on a router group variable covers that group, with a
+// path covers the routes under it, and inside a router closure covers the
+// closure; only path-less registrations elsewhere cover the whole file.
+func scanFileAuth(lines []string) *fileAuth {
+	a := &fileAuth{groups: make(map[string]bool), parents: make(map[string]string), blocks: make([]bool, len(lines))}
+	for _, line := range lines {
+		if m := reRouterGroup.FindStringSubmatch(line); m != nil {
+			a.parents[m[1]] = m[2]
+		}
+	}
+	for i, line := range lines {
+		if !isAuthMiddleware(line) {
+			continue
+		}
+		switch {
+		case reRouterGroup.MatchString(line):
+			a.groups[reRouterGroup.FindStringSubmatch(line)[1]] = true
+		case reAuthRouter.MatchString(line):
+			a.groups[reAuthRouter.FindStringSubmatch(line)[1]] = true
+		case reAuthUse.MatchString(line):
+			m := reAuthUse.FindStringSubmatchIndex(line)
+			if !isAuthMiddleware(line[m[1]:]) {
+				// The middleware arguments are not auth, as in
+				// passport.use(new JwtStrategy(...)).
+				continue
+			}
+			receiver, path := line[m[2]:m[3]], ""
+			if m[4] >= 0 {
+				path = line[m[4]:m[5]]
+			}
+			if _, group := a.parents[receiver]; path != "" {
+				a.prefixes = append(a.prefixes, path)
+			} else if group {
+				a.groups[receiver] = true
+			} else if start, ok := routerClosure(lines, i); ok {
+				a.markBlock(start, start+len(braceBlock(lines, start))-1)
+			} else {
+				a.global = true
+			}
+		case reGlobalAuthHook.MatchString(line) || isClassDecoration(lines, i):
+			a.global = true
+		case reAuthBlock.MatchString(line):
+			a.markBlock(i, authBlockEnd(lines, i))
+		}
+	}
+	return a
+}
+
+// markBlock marks the lines between a block's opening line start and its
+// closing line end as covered.
+func (a *fileAuth) markBlock(start, end int) {
+	for j := start + 1; j < end; j++ {
+		a.blocks[j] = true
+	}
+}
+
+// routerClosure returns the line opening the router closure that lines[i]
+// is directly inside, if the enclosing block is one: the nearest earlier
+// line indented less than lines[i].
+func routerClosure(lines []string, i int) (int, bool) {
+	indent := indentation(lines[i])
+	for j := i - 1; j >= 0; j-- {
+		if strings.TrimSpace(lines[j]) == "" || indentation(lines[j]) >= indent {
+			continue
+		}
+		return j, reRouterClosure.MatchString(lines[j])
+	}
+	return 0, false
+}
+
+// isAuthMiddleware reports whether code applies auth middleware. String
+// literals are ignored, so that a route path such as '/authenticate' does
+// not count, and so is passport setup, which enforces nothing.
+func isAuthMiddleware(code string) bool {
+	code = reQuotedString.ReplaceAllString(code, `""`)
+	return reAuthMiddleware.MatchString(reAuthSetup.ReplaceAllString(code, ""))
+}
+
+// authBlockEnd returns the index of the line that closes the authenticate
+// block opened on lines[i]: a closing brace, or a Ruby end.
+func authBlockEnd(lines []string, i int) int {
+	if strings.HasSuffix(strings.TrimSpace(lines[i]), "{") {
+		return i + len(braceBlock(lines, i)) - 1
+	}
+	depth := 0
+	for j := i; j < len(lines); j++ {
+		if reRubyBlockOpen.MatchString(lines[j]) {
+			depth++
+		}
+		if reRubyBlockClose.MatchString(lines[j]) {
+			if depth--; depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(lines)
+}
+
+// covers reports whether the route for endpoint declared on lines[i] is
+// covered by auth middleware registered beyond the route itself.
+func (a *fileAuth) covers(lines []string, i int, endpoint string) bool {
+	if a.global || a.blocks[i] {
+		return true
+	}
+	if m := reRouteReceiver.FindStringSubmatch(lines[i]); m != nil {
+		// Walk up the groups the receiver is derived from; the bound
+		// guards against cycles.
+		for g, n := m[1], 0; g != "" && n <= len(a.parents); g, n = a.parents[g], n+1 {
+			if a.groups[g] {
+				return true
+			}
+		}
+	}
+	for _, prefix := range a.prefixes {
+		prefix = strings.TrimRight(prefix, "/")
+		if endpoint == prefix || strings.HasPrefix(endpoint, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isClassDecoration reports whether lines[i] is a decorator, annotation,
+// or attribute on a class: the next line that is neither blank nor
+// another decoration declares one.
+func isClassDecoration(lines []string, i int) bool {
+	if !isDecoratorLine(lines[i]) {
+		return false
+	}
+	for _, line := range lines[i+1:] {
+		if strings.TrimSpace(line) == "" || isDecoratorLine(line) {
+			continue
+		}
+		return reCsClass.MatchString(line)
+	}
+	return false
+}
+
+// hasRouteAuth reports whether the route declared on lines[i] has auth
+// middleware chained on it: on the same line, in the decorators or
+// attributes stacked directly above it, or in the next routeAuthWindow
+// lines of the declaration (middleware arguments, a decorator below the
+// route decorator, a chained RequireAuthorization). The window ends at
+// the next route, at the decorators of the next handler, and where the
+// code dedents out of the declaration. Python decorators apply bottom-up,
+// so one above the route decorator wraps a function that was registered
+// unprotected and does not count.
+func hasRouteAuth(lines []string, i int, ext string) bool {
+	if isAuthMiddleware(lines[i]) {
+		return true
+	}
+	for j := i - 1; ext != ".py" && j >= max(0, i-routeAuthWindow); j-- {
+		if !isDecoratorLine(lines[j]) {
+			break
+		}
+		if isAuthMiddleware(lines[j]) {
+			return true
+		}
+	}
+	indent := indentation(lines[i])
+	pastDecorators := false
+	for j := i + 1; j < len(lines) && j <= i+routeAuthWindow; j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indentation(line) < indent || extractEndpoint(line, ext) != "" {
+			break
+		}
+		if isDecoratorLine(line) {
+			if pastDecorators {
+				break
+			}
+		} else {
+			pastDecorators = true
+		}
+		if isAuthMiddleware(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// indentation returns the width of line's leading whitespace.
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Endpoint sensitivity tags ---
+
+// sensitivityKeywords map lowercase path and handler keywords to the data
+// sensitivity category of endpoints that mention them.
+type sensitivityKeywords map[string]string
+
+// defaultSensitivityKeywords cover common payment card (pci), personal
+// (pii), health (phi), and credential terms. "health" itself is left out
+// since it names liveness checks far more often than medical data.
+var defaultSensitivityKeywords = sensitivityKeywords{
+	"payment": "pci", "card": "pci", "credit card": "pci", "cvv": "pci",
+	"billing": "pci", "checkout": "pci", "iban": "pci", "bank account": "pci",
+	"ssn": "pii", "pii": "pii", "personal": "pii", "passport": "pii",
+	"dob": "pii", "birthdate": "pii", "date of birth": "pii", "tax id": "pii",
+	"address": "pii", "phone": "pii", "kyc": "pii",
+	"medical": "phi", "patient": "phi", "diagnosis": "phi", "prescription": "phi",
+	"clinical": "phi", "hipaa": "phi", "phi": "phi",
+	"password": "credentials", "passwd": "credentials", "credential": "credentials",
+	"secret": "credentials", "otp": "credentials", "mfa": "credentials",
+}
+
+var (
+	// reCamelBoundary splits camelCase words: getCardDetails.
+	reCamelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+	// reNonWord splits words on punctuation, separators, and digits.
+	reNonWord = regexp.MustCompile(`[^A-Za-z]+`)
+)
+
+// parseSensitivityKeywords reads the sensitivity_keywords input. Tagging
+// uses the defaults unless the input is false. An object maps keywords to
+// categories on top of the defaults; an empty category drops a default.
+func parseSensitivityKeywords(v any) (sensitivityKeywords, error) {
+	switch t := v.(type) {
+	case nil:
+		return defaultSensitivityKeywords, nil
+	case bool:
+		if !t {
+			return nil, nil
+		}
+		return defaultSensitivityKeywords, nil
+	case map[string]any:
+		keywords := make(sensitivityKeywords, len(defaultSensitivityKeywords)+len(t))
+		for k, c := range defaultSensitivityKeywords {
+			keywords[k] = c
+		}
+		for keyword, raw := range t {
+			category, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("sensitivity_keywords category for %q must be a string", keyword)
+			}
+			key := sensitivityWords(keyword)
+			if key == "" {
+				return nil, fmt.Errorf("sensitivity_keywords keyword %q has no letters", keyword)
+			}
+			if category == "" {
+				delete(keywords, key)
+				continue
+			}
+			keywords[key] = strings.ToLower(category)
+		}
+		return keywords, nil
+	default:
+		return nil, fmt.Errorf("sensitivity_keywords must be a boolean or an object, got %T", v)
+	}
+}
+
+// sensitivityWords lowercases s and splits it into space-separated words
+// at camelCase boundaries and non-letters: "/api/creditCards/{id}" becomes
+// "api credit cards id".
+func sensitivityWords(s string) string {
+	s = reCamelBoundary.ReplaceAllString(s, "$1 $2")
+	return strings.Join(strings.Fields(strings.ToLower(reNonWord.ReplaceAllString(s, " "))), " ")
+}
+
+// categories returns the sorted categories and matched keywords of an
+// endpoint whose path or handler name contains a keyword as whole words,
+// allowing a plural "s".
+func (k sensitivityKeywords) categories(endpoint, handler string) (categories, matched []string) {
+	words := " " + sensitivityWords(endpoint+" "+handler) + " "
+	seen := make(map[string]bool)
+	for keyword, category := range k {
+		if !strings.Contains(words, " "+keyword+" ") && !strings.Contains(words, " "+keyword+"s ") {
+			continue
+		}
+		matched = append(matched, keyword)
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	sort.Strings(matched)
+	return categories, matched
+}
+
+// withSensitivity adds the sensitivity categories and matched keywords of
+// an endpoint to its finding, if any.
+func withSensitivity(f *sdk.FindingBuilder, keywords sensitivityKeywords, endpoint, handler string) {
+	categories, matched := keywords.categories(endpoint, handler)
+	if len(categories) == 0 {
+		return
+	}
+	f.WithMetadata("sensitivity", strings.Join(categories, ",")).
+		WithMetadata("sensitivity_keywords", strings.Join(matched, ","))
+}
+
+// routeHandlerName returns the name of the handler for the route defined
+// at lines[idx]: the named handler passed to the route, or the function a
+// route decorator or attribute annotates. It returns "" for inline
+// handlers.
+func routeHandlerName(lines []string, idx int) string {
+	if m := reNamedHandler.FindStringSubmatch(lines[idx]); len(m) > 1 {
+		return m[1]
+	}
+	if !isDecoratorLine(lines[idx]) {
+		return ""
+	}
+	for j := idx + 1; j < len(lines) && j <= idx+optOutWindow; j++ {
+		if name := declaredName(lines[j]); name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Pickled and client-side session backends ---
+
+// flaskCookieSession is Flask's default session interface, which stores
+// the session in a signed but readable cookie.
+const flaskCookieSession = "flask.sessions.SecureCookieSessionInterface"
+
+var (
+	// reSessionPickle matches session serializers that unpickle session
+	// data. Group 1 or 2 is the serializer.
+	reSessionPickle = regexp.MustCompile(`\bSESSION_SERIALIZER\s*=\s*['"]([\w.]*Pickle\w*)['"]|\bserializer\s*=\s*((?:\w+\.)*Pickle\w*)`)
+
+	// reDjangoSignedCookies matches Django's cookie-based session engine.
+	// Group 1 is the engine.
+	reDjangoSignedCookies = regexp.MustCompile(`\bSESSION_ENGINE\s*=\s*['"](django\.contrib\.sessions\.backends\.signed_cookies)['"]`)
+
+	// reFlaskSessionImport matches imports of Flask's session proxy.
+	reFlaskSessionImport = regexp.MustCompile(`^\s*from\s+flask\s+import\s+.*\bsession\b`)
+
+	// reServerSideSession matches server-side session stores that replace
+	// Flask's cookie session.
+	reServerSideSession = regexp.MustCompile(`\bSESSION_TYPE\b|\bSession\s*\(\s*app\s*\)|\bflask_session\b|\bsession_interface\s*=|\bKVSessionExtension\b`)
+
+	// reFlaskSessionWrite matches a write to Flask's session. Group 1 is
+	// the key.
+	reFlaskSessionWrite = regexp.MustCompile(`^\s*session\[\s*["'](\w+)["']\s*\]\s*=[^=]`)
+
+	// reSensitiveSessionKey matches session keys holding data that should
+	// not be readable by the client.
+	reSensitiveSessionKey = regexp.MustCompile(`(?i)password|passwd|secret|token|api_?key|ssn|card|credit|private|email|phone|address|birth`)
+)
+
+// unsafeSessionBackend returns the session backend configured on line and
+// how it serializes sessions ("pickle" or "signed"), or empty strings.
+// Flask cookie sessions are reported only for writes of a sensitive key,
+// when flaskCookies says the file uses Flask's default session.
+func unsafeSessionBackend(line string, flaskCookies bool) (backend, serialization, key string) {
+	if m := reSessionPickle.FindStringSubmatch(line); m != nil {
+		return m[1] + m[2], "pickle", ""
+	}
+	if m := reDjangoSignedCookies.FindStringSubmatch(line); m != nil {
+		return m[1], "signed", ""
+	}
+	if flaskCookies {
+		if m := reFlaskSessionWrite.FindStringSubmatch(line); m != nil && reSensitiveSessionKey.MatchString(m[1]) {
+			return flaskCookieSession, "signed", m[1]
+		}
+	}
+	return "", "", ""
+}
+
+// reportUnsafeSessionBackend emits ATTACK-096 when lines[i] configures a
+// pickle-based or signed-cookie session backend, or stores sensitive data
+// in Flask's default cookie session.
+func reportUnsafeSessionBackend(resp *sdk.ResponseBuilder, filePath string, lines []string, i int, flaskCookies bool) {
+	backend, serialization, key := unsafeSessionBackend(lines[i], flaskCookies)
+	if backend == "" {
+		return
+	}
+	message := fmt.Sprintf("Session data is signed but not encrypted (%s); clients can read it", backend)
+	switch {
+	case serialization == "pickle":
+		message = fmt.Sprintf("Sessions are deserialized with pickle (%s); a leaked secret key allows code execution", backend)
+	case key != "":
+		message = fmt.Sprintf("Sensitive value %q stored in Flask's client-side cookie session: %s", key, strings.TrimSpace(lines[i]))
+	}
+	f := newFinding(resp, "ATTACK-096", message).
+		At(filePath, i+1, i+1).
+		WithMetadata("backend", backend).
+		WithMetadata("serialization", serialization)
+	if key != "" {
+		f.WithMetadata("session_key", key)
+	}
+	f.Done()
+}
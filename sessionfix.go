@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Session fixation on login ---
+
+// sessionStores recognize writes of an authenticated identity into a
+// server-side session, and the calls that issue a fresh session ID for
+// that store. Group 1 of write is the session key.
+var sessionStores = []struct {
+	framework  string
+	write      *regexp.Regexp
+	regenerate *regexp.Regexp
+}{
+	{"express-session", regexp.MustCompile(`\breq\.session\.(\w+)\s*=[^=]`), regexp.MustCompile(`\breq\.session\.regenerate\s*\(`)},
+	{"koa-session", regexp.MustCompile(`\bctx\.session\.(\w+)\s*=[^=]`), regexp.MustCompile(`\bctx\.regenerateSession\s*\(|\bctx\.session\s*=\s*null\b`)},
+	{"django", regexp.MustCompile(`\brequest\.session\[\s*["'](\w+)["']\s*\]\s*=[^=]`), regexp.MustCompile(`\.cycle_key\s*\(|\.flush\s*\(|\b(?:auth\.)?login\s*\(\s*request\b`)},
+	{"flask", regexp.MustCompile(`^\s*session\[\s*["'](\w+)["']\s*\]\s*=[^=]`), regexp.MustCompile(`\bsession\.(?:clear|regenerate)\s*\(`)},
+	{"gorilla-sessions", regexp.MustCompile(`\.Values\[\s*"(\w+)"\s*\]\s*=[^=]`), regexp.MustCompile(`\bOptions\.MaxAge\s*=\s*-1\b|\.New\s*\(\s*r\s*,`)},
+	{"scs", regexp.MustCompile(`\.Put\s*\(\s*r\.Context\(\)\s*,\s*"(\w+)"`), regexp.MustCompile(`\.RenewToken\s*\(`)},
+	{"servlet", regexp.MustCompile(`\bsession\.setAttribute\s*\(\s*"(\w+)"`), regexp.MustCompile(`\bchangeSessionId\s*\(|\bsession\.invalidate\s*\(`)},
+	{"aspnet-session", regexp.MustCompile(`\.Session\.Set(?:String|Int32)?\s*\(\s*"(\w+)"`), regexp.MustCompile(`\.Session\.Clear\s*\(`)},
+}
+
+var (
+	// reIdentitySessionKey matches session keys that mark the session as
+	// authenticated.
+	reIdentitySessionKey = regexp.MustCompile(`(?i)user|uid|account|logged_?in|auth|role|principal|member`)
+
+	// reLoginPath matches endpoints that accept credentials.
+	reLoginPath = regexp.MustCompile(`(?i)/(?:log_?-?in|sign_?-?in|authenticate|auth)(?:/|$)|^(?:log_?-?in|sign_?-?in)/?$`)
+
+	// reLoginHandlerName matches handler names that process a login.
+	reLoginHandlerName = regexp.MustCompile(`(?i)^(?:(?:do|handle|post|process)_?)?(?:log_?in|sign_?in)(?:_?(?:view|handler|post|submit|user))?$`)
+
+	// rePasswordCheck matches password verification, the auth-success
+	// context of handlers not routed under a login path.
+	rePasswordCheck = regexp.MustCompile(`\bbcrypt\.compare|\bcheck_password\s*\(|\bverify_password\s*\(|\bcheckpw\s*\(|\bCompareHashAndPassword\s*\(|\bargon2\.verify\s*\(|\bpasswordEncoder\.matches\s*\(|\bVerifyHashedPassword\s*\(|\bPasswordSignInAsync\s*\(`)
+)
+
+// sessionFixation returns the session store and the identity write when
+// body logs a user in by writing to the session without regenerating the
+// session ID first.
+func sessionFixation(body []string) (framework, write string) {
+	for _, s := range sessionStores {
+		if anyLineMatches(body, s.regenerate) {
+			continue
+		}
+		for _, line := range body {
+			if m := s.write.FindStringSubmatch(line); m != nil && reIdentitySessionKey.MatchString(m[1]) {
+				return s.framework, strings.TrimSpace(line)
+			}
+		}
+	}
+	return "", ""
+}
+
+// reportSessionFixation emits ATTACK-081 for login handlers that store the
+// authenticated user in a session they did not regenerate. Login handlers
+// are routes under a login path, routes whose handler verifies a
+// password, and functions named like a login handler. endpoints maps line
+// indexes to the endpoints registered there.
+func reportSessionFixation(resp *sdk.ResponseBuilder, filePath, ext string, lines []string, defs map[string]int, endpoints map[int]string) {
+	reported := make(map[string]bool)
+	for i, line := range lines {
+		endpoint, handler := endpoints[i], declaredName(line)
+		if endpoint == "" && !reLoginHandlerName.MatchString(handler) {
+			continue
+		}
+		body := handlerBody(lines, defs, i, ext)
+		if endpoint != "" && !reLoginPath.MatchString(endpoint) && !anyLineMatches(body, rePasswordCheck) {
+			continue
+		}
+		// A route and the named handler it references share a body.
+		key := body[0] + "\x00" + strconv.Itoa(len(body))
+		if reported[key] {
+			continue
+		}
+		framework, write := sessionFixation(body)
+		if framework == "" {
+			continue
+		}
+		reported[key] = true
+
+		target := endpoint
+		if target == "" {
+			target = handler
+		}
+		f := newFinding(
+			resp,
+			"ATTACK-081",
+			fmt.Sprintf("Login handler %s stores the user in the %s session without regenerating the session ID: %s", target, framework, write),
+		).
+			At(filePath, i+1, i+1).
+			WithMetadata("framework", framework).
+			WithMetadata("session_write", write)
+		if endpoint != "" {
+			f.WithMetadata("endpoint", endpoint)
+		}
+		if handler != "" {
+			f.WithMetadata("handler", handler)
+		}
+		f.Done()
+	}
+}
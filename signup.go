@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// --- Anti-automation on registration endpoints ---
+
+// signupSegments are path segments of registration-like endpoints that
+// bots abuse for spam and account enumeration.
+var signupSegments = map[string]bool{
+	"signup":         true,
+	"sign-up":        true,
+	"sign_up":        true,
+	"register":       true,
+	"registration":   true,
+	"invite":         true,
+	"invites":        true,
+	"invitation":     true,
+	"invitations":    true,
+	"contact":        true,
+	"contact-us":     true,
+	"contact_us":     true,
+	"create-account": true,
+}
+
+var (
+	// reCaptcha matches CAPTCHA integrations: reCAPTCHA, hCaptcha,
+	// Cloudflare Turnstile, and framework CAPTCHA fields.
+	reCaptcha = regexp.MustCompile(`(?i)captcha|turnstile`)
+
+	// reRateLimit matches rate-limit and throttling middleware.
+	reRateLimit = regexp.MustCompile(`(?i)rate.?limit|\blimiter\b|throttl|slowapi|\bhttprate\b|tollbooth|\bBucket4j\b|\blimit\s*\(\s*["']\d+\s*(?:/|per)`)
+)
+
+// isSignupEndpoint reports whether a segment of endpoint names a
+// registration, invite, or contact form.
+func isSignupEndpoint(endpoint string) bool {
+	for _, segment := range strings.Split(strings.ToLower(endpoint), "/") {
+		if signupSegments[segment] {
+			return true
+		}
+	}
+	return false
+}
+
+// missingAntiAutomation returns the protections ("captcha", "rate_limit")
+// absent from a file registering a registration-like route.
+func missingAntiAutomation(lines []string) []string {
+	var missing []string
+	if !anyLineMatches(lines, reCaptcha) {
+		missing = append(missing, "captcha")
+	}
+	if !anyLineMatches(lines, reRateLimit) {
+		missing = append(missing, "rate_limit")
+	}
+	return missing
+}
+
+// antiAutomationMessage describes the protections an endpoint lacks.
+func antiAutomationMessage(method, endpoint string, missing []string) string {
+	what := "CAPTCHA"
+	switch strings.Join(missing, ",") {
+	case "rate_limit":
+		what = "rate limiting"
+	case "captcha,rate_limit":
+		what = "CAPTCHA or rate limiting"
+	}
+	return fmt.Sprintf("Registration-like endpoint %s %s has no %s", method, endpoint, what)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Spring Boot Actuator exposure ---
+
+const (
+	keyActuatorInclude   = "management.endpoints.web.exposure.include"
+	keyActuatorExclude   = "management.endpoints.web.exposure.exclude"
+	keyActuatorBasePath  = "management.endpoints.web.base-path"
+	keyShutdownEnabled   = "management.endpoint.shutdown.enabled"
+	defaultActuatorPath  = "/actuator"
+	springConfigWildcard = "*"
+)
+
+// reSpringConfigName matches application/bootstrap config files, including
+// profile variants such as application-prod.yml.
+var reSpringConfigName = regexp.MustCompile(`^(?:application|bootstrap)(?:-[\w.-]+)?\.(?:properties|ya?ml)$`)
+
+// dangerousActuatorEndpoints can dump memory or configuration, or stop the
+// application. shutdown is only active when explicitly enabled.
+var dangerousActuatorEndpoints = []string{"env", "heapdump", "threaddump", "jolokia", "shutdown"}
+
+// configEntry is a flattened config value and the line it was set on.
+type configEntry struct {
+	value string
+	line  int
+}
+
+// isSpringConfig reports whether name is a Spring Boot config file.
+func isSpringConfig(name string) bool {
+	return reSpringConfigName.MatchString(name)
+}
+
+// scanSpringConfig flags Actuator exposure configured in a Spring Boot
+// properties or YAML file (ATTACK-061): one finding for a wildcard include
+// and one per explicitly exposed dangerous endpoint. A returned error means
+// the file could not be read.
+func scanSpringConfig(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+
+	var cfg map[string]configEntry
+	if filepath.Ext(filePath) == ".properties" {
+		cfg = flattenProperties(lines)
+	} else {
+		cfg = flattenYAML(lines)
+	}
+
+	include, ok := cfg[keyActuatorInclude]
+	if !ok {
+		return nil
+	}
+	basePath := defaultActuatorPath
+	if bp, ok := cfg[keyActuatorBasePath]; ok && bp.value != "" {
+		basePath = strings.TrimRight(bp.value, "/")
+	}
+
+	included := splitConfigList(include.value)
+	excluded := make(map[string]bool)
+	for _, e := range splitConfigList(cfg[keyActuatorExclude].value) {
+		excluded[e] = true
+	}
+	wildcard := false
+	for _, e := range included {
+		if e == springConfigWildcard {
+			wildcard = true
+		}
+	}
+
+	shutdownEnabled := strings.EqualFold(cfg[keyShutdownEnabled].value, "true")
+	var dangerous []string
+	for _, ep := range dangerousActuatorEndpoints {
+		if excluded[ep] || (ep == "shutdown" && !shutdownEnabled) {
+			continue
+		}
+		if wildcard || containsString(included, ep) {
+			dangerous = append(dangerous, ep)
+		}
+	}
+
+	if wildcard {
+		f := newFinding(
+			resp,
+			"ATTACK-061",
+			fmt.Sprintf("Spring Actuator exposes all web endpoints (%s=*)", keyActuatorInclude),
+		).
+			At(filePath, include.line, include.line).
+			WithMetadata("exposed", springConfigWildcard).
+			WithMetadata("endpoint", basePath+"/*")
+		if len(dangerous) > 0 {
+			f.WithMetadata("dangerous", strings.Join(dangerous, ","))
+		}
+		f.Done()
+		return nil
+	}
+
+	for _, ep := range dangerous {
+		newFinding(
+			resp,
+			"ATTACK-061",
+			fmt.Sprintf("Spring Actuator exposes dangerous endpoint %s/%s", basePath, ep),
+		).
+			At(filePath, include.line, include.line).
+			WithMetadata("exposed", strings.Join(included, ",")).
+			WithMetadata("endpoint", basePath+"/"+ep).
+			WithMetadata("dangerous", ep).
+			Done()
+	}
+	return nil
+}
+
+// flattenProperties parses key=value (or key: value) lines.
+func flattenProperties(lines []string) map[string]configEntry {
+	cfg := make(map[string]configEntry)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		}
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep < 0 {
+			continue
+		}
+		key := strings.TrimSpace(trimmed[:sep])
+		cfg[key] = configEntry{value: unquote(strings.TrimSpace(trimmed[sep+1:])), line: i + 1}
+	}
+	return cfg
+}
+
+// flattenYAML parses the block-mapping subset of YAML used by Spring config
+// into dotted keys. Sequence items are joined with commas.
+func flattenYAML(lines []string) map[string]configEntry {
+	type frame struct {
+		indent int
+		key    string
+	}
+	cfg := make(map[string]configEntry)
+	var stack []frame
+	for i, line := range lines {
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = line[:idx]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if trimmed == "---" {
+			stack = stack[:0]
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				continue
+			}
+			key := joinKeys(stack, func(f frame) string { return f.key })
+			entry := cfg[key]
+			if entry.value != "" {
+				entry.value += ","
+			} else {
+				entry.line = i + 1
+			}
+			entry.value += unquote(strings.TrimSpace(item))
+			cfg[key] = entry
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, frame{indent: indent, key: strings.TrimSpace(key)})
+		if value = strings.TrimSpace(value); value != "" {
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			cfg[joinKeys(stack, func(f frame) string { return f.key })] = configEntry{value: unquote(value), line: i + 1}
+		}
+	}
+	return cfg
+}
+
+// joinKeys joins the keys of a YAML frame stack with dots.
+func joinKeys[T any](stack []T, key func(T) string) string {
+	parts := make([]string, len(stack))
+	for i, f := range stack {
+		parts[i] = key(f)
+	}
+	return strings.Join(parts, ".")
+}
+
+// splitConfigList splits a comma-separated config value into trimmed,
+// unquoted elements.
+func splitConfigList(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, ",") {
+		if v = unquote(strings.TrimSpace(v)); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// unquote strips one layer of matching single or double quotes.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
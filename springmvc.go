@@ -0,0 +1,154 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Spring MVC controller routes (Java) ---
+
+// maxAnnotationLines bounds how far a mapping annotation's arguments are
+// followed across lines.
+const maxAnnotationLines = 10
+
+var (
+	// reSpringMapping matches Spring MVC mapping annotations. Group 1 is
+	// the verb (Request for @RequestMapping) and group 2 the arguments.
+	reSpringMapping = regexp.MustCompile(`@(Get|Post|Put|Delete|Patch|Request)Mapping\b\s*(?:\((.*)\))?`)
+
+	// reSpringPositionalPath matches a path given as the annotation's
+	// first argument, alone or as the first element of an array.
+	reSpringPositionalPath = regexp.MustCompile(`^\s*\{?\s*"([^"]*)"`)
+
+	// reSpringNamedPath matches a path given as value = or path =.
+	reSpringNamedPath = regexp.MustCompile(`\b(?:value|path)\s*=\s*\{?\s*"([^"]*)"`)
+
+	// reSpringRequestMethod matches the verbs in @RequestMapping's method
+	// argument.
+	reSpringRequestMethod = regexp.MustCompile(`RequestMethod\.(\w+)`)
+
+	// reJavaType matches a class or interface declaration. Group 1 is the
+	// name.
+	reJavaType = regexp.MustCompile(`^\s*(?:(?:public|protected|private|abstract|final|static|sealed)\s+)*(?:class|interface)\s+(\w+)`)
+)
+
+// springAnnotation returns the text of the annotation starting on
+// lines[i], joined onto one line when its arguments span several, and the
+// index of its last line. Lines that do not open an unbalanced
+// parenthesis are returned as is. An annotation that is not closed within
+// maxAnnotationLines has unknown arguments and yields "", so that it
+// registers no route.
+func springAnnotation(lines []string, i int) (string, int) {
+	line := lines[i]
+	if !strings.Contains(line, "@") || parenDepth(line) <= 0 {
+		return line, i
+	}
+	parts := []string{strings.TrimSpace(line)}
+	depth := parenDepth(line)
+	end := i
+	for j := i + 1; j < len(lines) && j <= i+maxAnnotationLines && depth > 0; j++ {
+		parts = append(parts, strings.TrimSpace(lines[j]))
+		depth += parenDepth(lines[j])
+		end = j
+	}
+	if depth > 0 {
+		return "", i
+	}
+	return strings.Join(parts, " "), end
+}
+
+// parenDepth returns the change in parenthesis depth across line, ignoring
+// parentheses in string literals.
+func parenDepth(line string) int {
+	depth := 0
+	inString := false
+	for k := 0; k < len(line); k++ {
+		switch c := line[k]; {
+		case c == '\\' && inString:
+			k++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		}
+	}
+	return depth
+}
+
+// matchSpringMapping extracts the method and path of a (joined) mapping
+// annotation. A mapping without a path handles the controller's own route
+// and reports "/". @RequestMapping reports its single method, or "ANY"
+// when it lists none or several.
+func matchSpringMapping(text string) (method, endpoint string) {
+	m := reSpringMapping.FindStringSubmatch(text)
+	if m == nil {
+		return "", ""
+	}
+	args := m[2]
+	endpoint = "/"
+	if p := reSpringPositionalPath.FindStringSubmatch(args); p != nil {
+		endpoint = p[1]
+	} else if p := reSpringNamedPath.FindStringSubmatch(args); p != nil {
+		endpoint = p[1]
+	}
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	if m[1] != "Request" {
+		return routeMethod(m[1]), endpoint
+	}
+	if verbs := reSpringRequestMethod.FindAllStringSubmatch(args, -1); len(verbs) == 1 {
+		return routeMethod(verbs[0][1]), endpoint
+	}
+	return "ANY", endpoint
+}
+
+// springControllerTracker joins method-level mapping paths with the
+// class-level @RequestMapping prefix of the enclosing controller.
+type springControllerTracker struct {
+	// pending is a class-level mapping awaiting its class.
+	pending string
+	// prefix is the route of the current controller.
+	prefix string
+}
+
+// apply returns the full endpoint for the mapping annotation on lines[i]
+// ending at lines[end], or "" when the annotation maps a class rather
+// than a handler method.
+func (t *springControllerTracker) apply(lines []string, i, end int, endpoint string) string {
+	if reJavaType.MatchString(lines[i]) {
+		if endpoint != "" {
+			// @RequestMapping("/api") public class Api {
+			t.prefix, t.pending = endpoint, ""
+			return ""
+		}
+		t.prefix, t.pending = t.pending, ""
+	}
+	if endpoint == "" {
+		return ""
+	}
+	if isJavaTypeAnnotation(lines, end) {
+		t.pending = endpoint
+		return ""
+	}
+	return joinRoutePath("/"+strings.Trim(t.prefix, "/"), endpoint)
+}
+
+// isJavaTypeAnnotation reports whether the annotation ending on lines[end]
+// decorates a class or interface: the next line that is neither blank nor
+// another annotation (or its arguments) declares one.
+func isJavaTypeAnnotation(lines []string, end int) bool {
+	depth := 0
+	for _, line := range lines[end+1:] {
+		trimmed := strings.TrimSpace(line)
+		if depth > 0 || trimmed == "" || strings.HasPrefix(trimmed, "@") {
+			depth += parenDepth(line)
+			continue
+		}
+		return reJavaType.MatchString(trimmed)
+	}
+	return false
+}
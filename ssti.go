@@ -0,0 +1,82 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Server-side template injection ---
+
+// templateSinks compile or render a template from a source string. Group 1
+// is the first argument, i.e. the template source.
+var templateSinks = []struct {
+	engine string
+	re     *regexp.Regexp
+}{
+	{"jinja2", regexp.MustCompile(`\brender_template_string\s*\(\s*([^,)]+)`)},
+	{"jinja2", regexp.MustCompile(`\b(?:jinja2\.Template|from_string)\s*\(\s*([^,)]+)`)},
+	{"go-template", regexp.MustCompile(`\btemplate\.New\s*\([^)]*\)\s*\.Parse\s*\(\s*([^,)]+)`)},
+	{"handlebars", regexp.MustCompile(`\bHandlebars\.compile\s*\(\s*([^,)]+)`)},
+	{"ejs", regexp.MustCompile(`\bejs\.(?:render|compile)\s*\(\s*([^,)]+)`)},
+	{"pug", regexp.MustCompile(`\bpug\.(?:render|compile)\s*\(\s*([^,)]+)`)},
+	{"nunjucks", regexp.MustCompile(`\brenderString\s*\(\s*([^,)]+)`)},
+	{"mustache", regexp.MustCompile(`\bMustache\.render\s*\(\s*([^,)]+)`)},
+	{"lodash", regexp.MustCompile(`\b_\.template\s*\(\s*([^,)]+)`)},
+}
+
+var (
+	// Assignments whose right-hand side may be request input:
+	// name = request.args["n"], const tpl = req.body.t, t := r.FormValue("t").
+	reAssignment = regexp.MustCompile(`^\s*(?:(?:const|let|var)\s+)?(\w+)\s*:?=\s*(.+)$`)
+	// JS destructuring: const { template, name } = req.body.
+	reDestructure = regexp.MustCompile(`^\s*(?:const|let|var)\s*\{([^}]*)\}\s*=\s*(.+)$`)
+	reIdentifier  = regexp.MustCompile(`[A-Za-z_]\w*`)
+	// Template sources that are plain literals, with no f-string prefix,
+	// concatenation, or interpolation.
+	rePlainLiteral = regexp.MustCompile("^(?:\"[^\"]*\"|'[^']*'|`[^`$]*`)$")
+)
+
+// requestTaintedNames returns identifiers assigned directly from request
+// input anywhere in lines.
+func requestTaintedNames(lines []string) map[string]bool {
+	tainted := make(map[string]bool)
+	for _, line := range lines {
+		if m := reDestructure.FindStringSubmatch(line); len(m) > 2 && reRequestInput.MatchString(m[2]) {
+			for _, name := range strings.Split(m[1], ",") {
+				name, _, _ = strings.Cut(name, ":")
+				if name = strings.TrimSpace(name); name != "" {
+					tainted[name] = true
+				}
+			}
+			continue
+		}
+		if m := reAssignment.FindStringSubmatch(line); len(m) > 2 && reRequestInput.MatchString(m[2]) {
+			tainted[m[1]] = true
+		}
+	}
+	return tainted
+}
+
+// templateInjection returns the template engine when line compiles or
+// renders a template whose source is request input, either directly or
+// through a variable in tainted, or "". Rendering a fixed template with
+// request data as context is not reported.
+func templateInjection(line string, tainted map[string]bool) string {
+	for _, sink := range templateSinks {
+		m := sink.re.FindStringSubmatch(line)
+		if len(m) < 2 {
+			continue
+		}
+		source := strings.TrimSpace(m[1])
+		if rePlainLiteral.MatchString(source) {
+			continue
+		}
+		if reRequestInput.MatchString(source) {
+			return sink.engine
+		}
+		if usesTainted(source, tainted) {
+			return sink.engine
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Git submodules ---
+
+var (
+	reSubmoduleSection = regexp.MustCompile(`^\s*\[submodule\s+"([^"]+)"\s*\]`)
+	reSubmodulePath    = regexp.MustCompile(`^\s*path\s*=\s*(.+?)\s*$`)
+)
+
+// submodule is a submodule declared in .gitmodules. dir is relative to the
+// workspace root with forward slashes.
+type submodule struct {
+	name string
+	dir  string
+}
+
+// submodules are the submodules of the scanned workspace.
+type submodules []submodule
+
+// loadSubmodules parses .gitmodules at the workspace root. A workspace
+// without one has no submodules.
+func loadSubmodules(root string) (submodules, error) {
+	lines, err := readLines(filepath.Join(root, ".gitmodules"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var subs submodules
+	name := ""
+	for _, line := range lines {
+		if m := reSubmoduleSection.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			continue
+		}
+		if m := reSubmodulePath.FindStringSubmatch(line); m != nil && name != "" {
+			subs = append(subs, submodule{name: name, dir: path.Clean(filepath.ToSlash(m[1]))})
+		}
+	}
+	return subs, nil
+}
+
+// at returns the name of the submodule containing file, or "". file may be
+// absolute or relative to root.
+func (s submodules) at(root, file string) string {
+	rel := relativePath(root, file)
+	for _, sub := range s {
+		if rel == sub.dir || strings.HasPrefix(rel, sub.dir+"/") {
+			return sub.name
+		}
+	}
+	return ""
+}
+
+// tag adds submodule metadata to findings located inside a submodule.
+func (s submodules) tag(out *pluginv1.InvokeToolResponse, root string) {
+	if len(s) == 0 {
+		return
+	}
+	for _, f := range out.GetFindings() {
+		name := s.at(root, f.GetLocation().GetFilePath())
+		if name == "" {
+			continue
+		}
+		if f.Metadata == nil {
+			f.Metadata = map[string]string{}
+		}
+		f.Metadata["submodule"] = name
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+)
+
+// --- Accepted-finding suppressions ---
+
+// suppressionSet is the set of finding fingerprints listed in a
+// suppressions file. Entries are rule:file:location keys or the hashed
+// fingerprints findings carry in metadata.
+type suppressionSet struct {
+	path         string
+	root         string
+	fingerprints map[string]bool
+	stable       *fingerprinter
+}
+
+// loadSuppressions reads a suppressions file: one fingerprint per line,
+// blank lines and lines starting with # ignored.
+func loadSuppressions(path, workspaceRoot string) (*suppressionSet, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &suppressionSet{path: path, root: workspaceRoot, fingerprints: make(map[string]bool), stable: newFingerprinter(workspaceRoot)}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s.fingerprints[line] = true
+	}
+	return s, nil
+}
+
+// fingerprint identifies a finding as rule:file:location, where file is
+// relative to the workspace root and location is the normalized endpoint
+// when the finding has one (so it survives line shifts) and the start line
+// otherwise. For example "ATTACK-002:api/routes.go:/admin/users/{}" or
+// "ATTACK-004:app.py:42".
+func (s *suppressionSet) fingerprint(f *pluginv1.Finding) string {
+	file := f.GetLocation().GetFilePath()
+	if rel, err := filepath.Rel(s.root, file); err == nil {
+		file = filepath.ToSlash(rel)
+	}
+	location := strconv.Itoa(int(f.GetLocation().GetStartLine()))
+	if ep := f.GetMetadata()["endpoint"]; ep != "" {
+		location = normalizeEndpoint(ep)
+	}
+	return fmt.Sprintf("%s:%s:%s", f.GetRuleId(), file, location)
+}
+
+// suppressed reports whether f is listed. Diagnostics are never suppressed.
+func (s *suppressionSet) suppressed(f *pluginv1.Finding) bool {
+	return f.GetRuleId() != "ATTACK-000" && (s.fingerprints[s.fingerprint(f)] || s.fingerprints[s.stable.fingerprint(f)])
+}
+
+// count returns how many of findings are listed.
+func (s *suppressionSet) count(findings []*pluginv1.Finding) int {
+	n := 0
+	for _, f := range findings {
+		if s.suppressed(f) {
+			n++
+		}
+	}
+	return n
+}
+
+// apply removes listed findings from out.
+func (s *suppressionSet) apply(out *pluginv1.InvokeToolResponse) {
+	kept := out.Findings[:0]
+	for _, f := range out.GetFindings() {
+		if !s.suppressed(f) {
+			kept = append(kept, f)
+		}
+	}
+	out.Findings = kept
+}
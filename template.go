@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Server-rendered templates ---
+
+// templateExtensions lists the template files scanned with scan_templates.
+var templateExtensions = map[string]bool{
+	".html":   true,
+	".htm":    true,
+	".ejs":    true,
+	".jinja":  true,
+	".jinja2": true,
+	".j2":     true,
+	".erb":    true,
+}
+
+// templateFormFramework is the framework reported for form targets.
+const templateFormFramework = "template-form"
+
+var (
+	// reFormTag matches the start of a form element.
+	reFormTag = regexp.MustCompile(`(?i)<form\b`)
+
+	// reFormAction and reFormMethod read form attributes. Group 1 is the
+	// value.
+	reFormAction = regexp.MustCompile(`(?i)\saction\s*=\s*["']([^"']*)["']`)
+	reFormMethod = regexp.MustCompile(`(?i)\smethod\s*=\s*["']?(\w+)`)
+
+	// reInlineHandler matches inline event handler attributes. Group 1 is
+	// the attribute.
+	reInlineHandler = regexp.MustCompile(`(?i)<[a-z][^>]*?\s(on[a-z]+)\s*=\s*["']`)
+
+	// reJavaScriptURL matches javascript: URLs in link and form attributes.
+	reJavaScriptURL = regexp.MustCompile(`(?i)\s(?:href|src|action|formaction)\s*=\s*["']\s*javascript:`)
+
+	// reTemplateExpr matches template expressions, which make an action
+	// unresolvable statically.
+	reTemplateExpr = regexp.MustCompile(`\{\{|\{%|<%|\$\{`)
+)
+
+// formTag returns the form element starting on lines[idx], joined across
+// lines up to its closing ">".
+func formTag(lines []string, idx int) string {
+	tag := lines[idx][reFormTag.FindStringIndex(lines[idx])[0]:]
+	for j := idx + 1; !strings.Contains(tag, ">") && j < len(lines) && j <= idx+optOutWindow; j++ {
+		tag += " " + strings.TrimSpace(lines[j])
+	}
+	if end := strings.Index(tag, ">"); end >= 0 {
+		tag = tag[:end+1]
+	}
+	return tag
+}
+
+// formTarget returns the method and endpoint a form submits to, or "" for
+// the endpoint when the action is missing, external, or computed by a
+// template expression.
+func formTarget(tag string) (method, endpoint string) {
+	method = "GET"
+	if m := reFormMethod.FindStringSubmatch(tag); m != nil {
+		method = strings.ToUpper(m[1])
+	}
+	m := reFormAction.FindStringSubmatch(tag)
+	if m == nil {
+		return method, ""
+	}
+	action := strings.TrimSpace(m[1])
+	if !strings.HasPrefix(action, "/") || strings.HasPrefix(action, "//") || reTemplateExpr.MatchString(action) {
+		return method, ""
+	}
+	action, _, _ = strings.Cut(action, "?")
+	action, _, _ = strings.Cut(action, "#")
+	if !validEndpoint(action, templateFormFramework) {
+		return method, ""
+	}
+	return method, action
+}
+
+// inlineHandlers returns the sorted inline event handler attributes and
+// javascript: URLs on line.
+func inlineHandlers(line string) []string {
+	seen := make(map[string]bool)
+	for _, m := range reInlineHandler.FindAllStringSubmatch(line, -1) {
+		seen[strings.ToLower(m[1])] = true
+	}
+	if reJavaScriptURL.MatchString(line) {
+		seen["javascript:"] = true
+	}
+	handlers := make([]string, 0, len(seen))
+	for h := range seen {
+		handlers = append(handlers, h)
+	}
+	sort.Strings(handlers)
+	return handlers
+}
+
+// scanTemplate reports the endpoints forms in a template submit to as
+// ATTACK-001 with source "template", recording them in the inventory,
+// and flags inline JavaScript handlers (ATTACK-084). A returned error
+// means the file could not be read.
+func scanTemplate(resp *sdk.ResponseBuilder, filePath string, opts *scanOptions) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	for i, line := range lines {
+		lineNum := i + 1
+
+		if reFormTag.MatchString(line) {
+			if method, endpoint := formTarget(formTag(lines, i)); endpoint != "" {
+				f := newFinding(
+					resp,
+					"ATTACK-001",
+					fmt.Sprintf("HTTP endpoint referenced by template form: %s %s", method, endpoint),
+				).
+					At(filePath, lineNum, lineNum).
+					WithMetadata("endpoint", endpoint).
+					WithMetadata("method", method).
+					WithMetadata("framework", templateFormFramework).
+					WithMetadata("source", "template")
+				withSensitivity(f, opts.sensitivity, endpoint, "")
+				f.Done()
+				if opts.inventory != nil {
+					opts.inventory.recordFrom("template", endpoint, templateFormFramework, filePath, lineNum)
+				}
+			}
+		}
+
+		// ATTACK-084: Inline JavaScript event handlers and URLs.
+		if handlers := inlineHandlers(line); len(handlers) > 0 {
+			newFinding(
+				resp,
+				"ATTACK-084",
+				fmt.Sprintf("Inline JavaScript handler in template (%s): %s", strings.Join(handlers, ", "), strings.TrimSpace(line)),
+			).
+				At(filePath, lineNum, lineNum).
+				WithMetadata("handlers", strings.Join(handlers, ",")).
+				Done()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Terraform exposure ---
+
+var (
+	// reHCLBlockOpen matches a block header such as
+	// resource "aws_security_group" "web" {. Groups: type, labels.
+	reHCLBlockOpen = regexp.MustCompile(`^\s*([\w-]+)((?:\s+"[^"]*")*)\s*\{\s*$`)
+
+	// reHCLAttribute matches key = value. Groups: key, value.
+	reHCLAttribute = regexp.MustCompile(`^\s*([\w-]+)\s*=\s*(.*?)\s*$`)
+
+	// reHCLString matches a quoted string. Group 1 is its content.
+	reHCLString = regexp.MustCompile(`"([^"]*)"`)
+
+	// reHCLComment matches a trailing # or // comment outside strings.
+	reHCLComment = regexp.MustCompile(`\s*(?:#|//)[^"]*$`)
+)
+
+// openCIDRs are sources that admit the whole internet.
+var openCIDRs = map[string]bool{
+	"0.0.0.0/0": true,
+	"::/0":      true,
+	"*":         true,
+	"Internet":  true,
+	"Any":       true,
+}
+
+// hclBlock is a parsed HCL block with its attributes, as raw expressions,
+// and nested blocks.
+type hclBlock struct {
+	kind   string
+	labels []string
+	line   int
+	attrs  map[string]string
+	blocks []*hclBlock
+}
+
+// attr returns the string value of an attribute, unquoted.
+func (b *hclBlock) attr(key string) string {
+	return unquote(b.attrs[key])
+}
+
+// list returns the quoted strings of an attribute, which may be a list or
+// a single string.
+func (b *hclBlock) list(key string) []string {
+	var out []string
+	for _, m := range reHCLString.FindAllStringSubmatch(b.attrs[key], -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// children returns the nested blocks of kind.
+func (b *hclBlock) children(kind string) []*hclBlock {
+	var out []*hclBlock
+	for _, c := range b.blocks {
+		if c.kind == kind {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// parseHCL parses the block structure of a Terraform file. It reads
+// multi-line list attributes and treats map attributes (tags = {) as
+// blocks; heredocs and expressions are kept as raw text.
+func parseHCL(lines []string) []*hclBlock {
+	root := &hclBlock{attrs: map[string]string{}}
+	stack := []*hclBlock{root}
+	for i := 0; i < len(lines); i++ {
+		line := reHCLComment.ReplaceAllString(lines[i], "")
+		top := stack[len(stack)-1]
+		if strings.TrimSpace(line) == "}" {
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		if m := reHCLBlockOpen.FindStringSubmatch(line); m != nil {
+			b := &hclBlock{kind: m[1], line: i + 1, attrs: map[string]string{}}
+			for _, l := range reHCLString.FindAllStringSubmatch(m[2], -1) {
+				b.labels = append(b.labels, l[1])
+			}
+			top.blocks = append(top.blocks, b)
+			stack = append(stack, b)
+			continue
+		}
+		m := reHCLAttribute.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		if value == "{" {
+			b := &hclBlock{kind: m[1], line: i + 1, attrs: map[string]string{}}
+			top.blocks = append(top.blocks, b)
+			stack = append(stack, b)
+			continue
+		}
+		for strings.Count(value, "[") > strings.Count(value, "]") && i+1 < len(lines) {
+			i++
+			value += " " + strings.TrimSpace(reHCLComment.ReplaceAllString(lines[i], ""))
+		}
+		top.attrs[m[1]] = value
+	}
+	return root.blocks
+}
+
+// openSources returns the sources among values that admit the internet.
+func openSources(values ...string) []string {
+	var open []string
+	for _, v := range values {
+		if openCIDRs[v] && !containsString(open, v) {
+			open = append(open, v)
+		}
+	}
+	return open
+}
+
+// portRange formats a from/to port pair; "all" covers every port.
+func portRange(from, to, protocol string) string {
+	switch {
+	case protocol == "-1" || strings.EqualFold(protocol, "all"):
+		return "all"
+	case from == "" || from == "*":
+		return "all"
+	case from == "0" && (to == "0" || to == "65535"):
+		return "all"
+	case to == "" || to == from:
+		return from
+	}
+	return from + "-" + to
+}
+
+// terraformExposure is an internet-facing resource in Terraform.
+type terraformExposure struct {
+	resource string
+	kind     string
+	port     string
+	cidr     string
+	line     int
+}
+
+// terraformExposures returns the internet-facing ingress rules, load
+// balancers, and databases declared by the resources in blocks.
+func terraformExposures(blocks []*hclBlock) []terraformExposure {
+	var out []terraformExposure
+	ingress := func(resource string, b *hclBlock, port string, sources []string) {
+		if open := openSources(sources...); len(open) > 0 {
+			out = append(out, terraformExposure{resource: resource, kind: "ingress", port: port, cidr: strings.Join(open, ","), line: b.line})
+		}
+	}
+	for _, r := range blocks {
+		if r.kind != "resource" || len(r.labels) != 2 {
+			continue
+		}
+		resource := r.labels[0] + "." + r.labels[1]
+		switch r.labels[0] {
+		case "aws_security_group":
+			for _, b := range r.children("ingress") {
+				ingress(resource, b, portRange(b.attr("from_port"), b.attr("to_port"), b.attr("protocol")), append(b.list("cidr_blocks"), b.list("ipv6_cidr_blocks")...))
+			}
+		case "aws_security_group_rule":
+			if r.attr("type") == "ingress" {
+				ingress(resource, r, portRange(r.attr("from_port"), r.attr("to_port"), r.attr("protocol")), append(r.list("cidr_blocks"), r.list("ipv6_cidr_blocks")...))
+			}
+		case "aws_vpc_security_group_ingress_rule":
+			ingress(resource, r, portRange(r.attr("from_port"), r.attr("to_port"), r.attr("ip_protocol")), []string{r.attr("cidr_ipv4"), r.attr("cidr_ipv6")})
+		case "google_compute_firewall":
+			if d := r.attr("direction"); d != "" && d != "INGRESS" {
+				continue
+			}
+			var ports []string
+			for _, b := range r.children("allow") {
+				ports = append(ports, b.list("ports")...)
+				if len(b.list("ports")) == 0 {
+					ports = append(ports, "all")
+				}
+			}
+			ingress(resource, r, strings.Join(ports, ","), r.list("source_ranges"))
+		case "azurerm_network_security_rule":
+			if azureInboundAllow(r) {
+				ingress(resource, r, azurePorts(r), append(r.list("source_address_prefixes"), r.attr("source_address_prefix")))
+			}
+		case "azurerm_network_security_group":
+			for _, b := range r.children("security_rule") {
+				if azureInboundAllow(b) {
+					ingress(resource, b, azurePorts(b), append(b.list("source_address_prefixes"), b.attr("source_address_prefix")))
+				}
+			}
+		case "aws_lb", "aws_alb", "aws_elb":
+			if v := r.attr("internal"); v == "" || v == "false" {
+				out = append(out, terraformExposure{resource: resource, kind: "load_balancer", line: r.line})
+			}
+		case "aws_db_instance", "aws_rds_cluster_instance", "aws_redshift_cluster":
+			if r.attr("publicly_accessible") == "true" {
+				out = append(out, terraformExposure{resource: resource, kind: "database", port: r.attr("port"), line: r.line})
+			}
+		}
+	}
+	return out
+}
+
+// azureInboundAllow reports whether an Azure security rule allows inbound
+// traffic.
+func azureInboundAllow(b *hclBlock) bool {
+	return strings.EqualFold(b.attr("direction"), "Inbound") && strings.EqualFold(b.attr("access"), "Allow")
+}
+
+// azurePorts returns the destination ports of an Azure security rule.
+func azurePorts(b *hclBlock) string {
+	ports := b.list("destination_port_ranges")
+	if p := b.attr("destination_port_range"); p != "" {
+		ports = append(ports, p)
+	}
+	if len(ports) == 0 || containsString(ports, "*") {
+		return "all"
+	}
+	return strings.Join(ports, ",")
+}
+
+// scanTerraform flags internet-facing ingress rules, load balancers, and
+// databases in a Terraform file (ATTACK-092). A returned error means the
+// file could not be read.
+func scanTerraform(resp *sdk.ResponseBuilder, filePath string) error {
+	lines, err := readLines(filePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range terraformExposures(parseHCL(lines)) {
+		var message string
+		switch e.kind {
+		case "ingress":
+			message = fmt.Sprintf("%s allows ingress from %s on port %s", e.resource, e.cidr, e.port)
+		case "load_balancer":
+			message = fmt.Sprintf("Load balancer %s is internet-facing", e.resource)
+		case "database":
+			message = fmt.Sprintf("Database %s is publicly accessible", e.resource)
+		}
+		f := newFinding(resp, "ATTACK-092", message).
+			At(filePath, e.line, e.line).
+			WithMetadata("resource", e.resource).
+			WithMetadata("kind", e.kind)
+		if e.port != "" {
+			f.WithMetadata("port", e.port)
+		}
+		if e.cidr != "" {
+			f.WithMetadata("cidr", e.cidr)
+		}
+		f.Done()
+	}
+	return nil
+}
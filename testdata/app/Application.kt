@@ -0,0 +1,28 @@
+package com.example
+
+import io.ktor.server.application.*
+import io.ktor.server.response.*
+import io.ktor.server.routing.*
+
+// Ktor routes — triggers ATTACK-001 and ATTACK-002.
+fun Application.configureRouting() {
+    routing {
+        route("/api") {
+            get("/accounts") {
+                call.respondText("accounts")
+            }
+            route("/v2") {
+                post("/transfers") {
+                    call.respondText("ok")
+                }
+            }
+        }
+    }
+}
+
+// Micronaut controller — triggers ATTACK-001.
+@Controller
+class ReportController {
+    @Get("/reports")
+    fun list(): List<String> = emptyList()
+}
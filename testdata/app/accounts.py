@@ -0,0 +1,16 @@
+import hashlib
+
+import bcrypt
+
+
+def legacy_password_digest(password):
+    # Weak password hashing — triggers ATTACK-062.
+    return hashlib.md5(password.encode()).hexdigest()
+
+
+def password_digest(password):
+    return bcrypt.hashpw(password.encode(), bcrypt.gensalt())
+
+
+def etag(body):
+    return hashlib.sha1(body).hexdigest()
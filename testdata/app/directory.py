@@ -0,0 +1,19 @@
+import ldap
+from flask import Flask, request
+
+app = Flask(__name__)
+conn = ldap.initialize("ldap://ldap.internal")
+
+
+@app.route("/directory/lookup")
+def lookup():
+    username = request.args.get("username")
+    # Unescaped filter — triggers ATTACK-066.
+    return str(conn.search_s("ou=people,dc=example,dc=com", ldap.SCOPE_SUBTREE, "(&(uid=" + username + "))"))
+
+
+@app.route("/directory/safe")
+def safe_lookup():
+    username = request.args.get("username")
+    flt = "(uid=%s)" % ldap.filter.escape_filter_chars(username)
+    return str(conn.search_s("ou=people,dc=example,dc=com", ldap.SCOPE_SUBTREE, flt))
@@ -0,0 +1,7 @@
+var builder = WebApplication.CreateBuilder(args);
+var app = builder.Build();
+
+app.MapGet("/ping", () => "pong");
+app.MapPost("/orders", (Order order) => Results.Created($"/orders/{order.Id}", order));
+
+app.Run();
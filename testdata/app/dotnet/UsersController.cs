@@ -0,0 +1,18 @@
+using Microsoft.AspNetCore.Authorization;
+using Microsoft.AspNetCore.Mvc;
+
+namespace Inventory.Api.Controllers;
+
+[ApiController]
+[Route("api/[controller]")]
+public class UsersController : ControllerBase
+{
+    [HttpGet]
+    public IActionResult List() => Ok();
+
+    [HttpGet("{id}")]
+    public IActionResult Get(int id) => Ok();
+
+    [HttpDelete("{id}")]
+    public IActionResult Delete(int id) => NoContent();
+}
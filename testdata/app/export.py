@@ -0,0 +1,16 @@
+import csv
+import io
+
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+# CSV export of request data without formula neutralization — triggers ATTACK-055.
+@app.route("/reports/export", methods=["POST"])
+def export_report():
+    buf = io.StringIO()
+    writer = csv.writer(buf)
+    for row in request.json["rows"]:
+        writer.writerow([row["name"], row["comment"]])
+    return buf.getvalue()
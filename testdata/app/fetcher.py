@@ -0,0 +1,18 @@
+import requests
+from flask import Flask, request
+
+app = Flask(__name__)
+
+
+# Host-controlled SSRF — triggers ATTACK-006 (Critical).
+@app.route("/proxy")
+def proxy():
+    url = "http://" + request.args.get("host") + "/status"
+    return requests.get(url).text
+
+
+# Path-controlled SSRF — triggers ATTACK-006 (High).
+@app.route("/avatar")
+def avatar():
+    url = "http://images.internal/avatars/" + request.args.get("name")
+    return requests.get(url).content
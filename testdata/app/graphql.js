@@ -0,0 +1,26 @@
+const { ApolloServer } = require('@apollo/server');
+
+const typeDefs = gql`
+  type Mutation {
+    createPost(title: String!): Post @auth
+    deleteUser(id: ID!): Boolean
+  }
+`;
+
+const resolvers = {
+  Mutation: {
+    createPost: async (_, { title }, context) => {
+      return posts.create({ title, author: context.user.id });
+    },
+    // No auth directive or check — triggers ATTACK-065.
+    deleteUser: async (_, { id }) => {
+      return users.remove(id);
+    },
+  },
+};
+
+// GraphQL server without depth/cost/batch limits — triggers ATTACK-051.
+const server = new ApolloServer({
+  typeDefs,
+  resolvers,
+});
@@ -0,0 +1,17 @@
+from flask import Flask, render_template_string, request
+
+app = Flask(__name__)
+
+
+@app.route("/greet")
+def greet():
+    name = request.args.get("name", "")
+    # Safe: fixed template, user data passed as context.
+    return render_template_string("Hello {{ name }}", name=name)
+
+
+@app.route("/preview")
+def preview():
+    template = request.form["template"]
+    # User-controlled template source — triggers ATTACK-067.
+    return render_template_string(template)
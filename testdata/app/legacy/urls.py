@@ -0,0 +1,14 @@
+from django.urls import re_path
+import tornado.web
+
+from . import views
+
+urlpatterns = [
+    re_path(r'^articles/(?P<year>[0-9]{4})/$', views.year_archive),
+    re_path(r'^reports/(\d+)/download/$', views.download_report),
+]
+
+application = tornado.web.Application([
+    (r"/user/([0-9]+)", UserHandler),
+    (r"/feeds/(?P<slug>[\w-]+)\.xml", FeedHandler),
+])
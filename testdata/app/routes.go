@@ -13,6 +13,9 @@ func SetupRoutes() {
 	http.HandleFunc("/admin/dashboard", handleAdmin)
 	http.HandleFunc("/debug/pprof", handleDebug)
 	http.HandleFunc("/internal/metrics", handleMetrics)
+
+	// Binds every interface next to admin/debug routes — triggers ATTACK-059.
+	_ = http.ListenAndServe(":8080", nil)
 }
 
 // File upload — triggers ATTACK-004.
@@ -21,6 +24,11 @@ func handleUpload(w http.ResponseWriter, r *http.Request) {
 	_ = file
 }
 
+// Raw http.Handler that dispatches internally — triggers ATTACK-052.
+type apiGateway struct{}
+
+func (g *apiGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
 func handleUsers(w http.ResponseWriter, r *http.Request) {}
 func handleOrders(w http.ResponseWriter, r *http.Request) {}
 func handleAdmin(w http.ResponseWriter, r *http.Request)  {}
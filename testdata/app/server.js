@@ -1,12 +1,17 @@
 const express = require('express');
 const app = express();
 
+// Oversized body limit — triggers ATTACK-056.
+app.use(express.json({ limit: '50mb' }));
+
 // HTTP endpoints — triggers ATTACK-001 and ATTACK-002.
 app.get('/api/products', (req, res) => {
   res.json({ products: [] });
 });
 
 app.post('/api/checkout', (req, res) => {
+  // Logging the whole request body — triggers ATTACK-050.
+  console.log(req.body);
   res.json({ status: 'ok' });
 });
 
@@ -15,6 +20,11 @@ app.get('/admin/settings', (req, res) => {
   res.json({ settings: {} });
 });
 
+// Health check leaking internals — triggers ATTACK-054.
+app.get('/health', (req, res) => {
+  res.json({ status: 'ok', version: process.version, database: db.state });
+});
+
 // File upload — triggers ATTACK-004.
 const multer = require('multer');
 const upload = multer({ dest: 'uploads/' });
@@ -0,0 +1,14 @@
+const mongoose = require('mongoose');
+const router = require('express').Router();
+const User = mongoose.model('User');
+
+// NoSQL injection sinks — trigger ATTACK-057.
+router.post('/users/search', async (req, res) => {
+  const users = await User.find(req.body);
+  res.json(users);
+});
+
+router.get('/users/lookup', async (req, res) => {
+  const user = await User.findOne({ $where: req.query.filter });
+  res.json(user);
+});
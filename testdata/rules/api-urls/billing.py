@@ -0,0 +1 @@
+BILLING_URL = 'http://billing-prod.example.io/api/invoices'
@@ -0,0 +1,6 @@
+const BASE = "https://api.prod.internal/v2";
+// Docs: "https://api.prod.internal/docs"
+fetch("https://api.prod.internal/v2/users");
+const stripe = "https://api.stripe.com/v1/charges";
+const lib = "https://cdn.jsdelivr.net/npm/lodash/lodash.min.js";
+const dev = "http://localhost:3000/api";
@@ -0,0 +1,7 @@
+[Authorize(Roles = "Admin")]
+[Route("admin")]
+public class AdminController : Controller
+{
+    [HttpPost("reindex")]
+    public IActionResult Reindex() => Ok();
+}
@@ -0,0 +1,7 @@
+package main
+
+func fetch() {
+	req, _ := http.NewRequest("GET", "https://billing.internal/api/v1/invoices", nil)
+	req.SetBasicAuth("svc-billing", "s3cr3t-Pa55")
+	url := fmt.Sprintf("https://%s:%s@api.example.com/x", user, pass)
+}
@@ -0,0 +1,8 @@
+package main
+
+func routes() { http.HandleFunc("/api/items", createItem) }
+
+func createItem(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	_ = json.NewDecoder(r.Body).Decode(&item)
+}
@@ -0,0 +1 @@
+DATA_UPLOAD_MAX_MEMORY_SIZE = None
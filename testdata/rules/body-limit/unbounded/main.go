@@ -0,0 +1,7 @@
+package main
+
+func routes() { http.HandleFunc("/api/items", createItem) }
+
+func createItem(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewDecoder(r.Body).Decode(&item)
+}
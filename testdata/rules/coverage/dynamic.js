@@ -0,0 +1,3 @@
+const express = require('express');
+
+for (const r of table) router[r.verb](r.path, r.fn);
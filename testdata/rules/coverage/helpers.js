@@ -0,0 +1 @@
+module.exports = { add: (a, b) => a + b };
@@ -0,0 +1,2 @@
+const express = require('express');
+app.get('/api/users', handler);
@@ -0,0 +1,3 @@
+services.AddCors(options => options.AddPolicy("open", policy => policy
+    .SetIsOriginAllowed(_ => true)
+    .AllowCredentials()));
@@ -0,0 +1,10 @@
+app.use(cors({
+  origin: true,
+  credentials: true,
+}));
+app.use(cors({ origin: 'https://app.example.com', credentials: true }));
+app.use((req, res, next) => {
+  res.setHeader('Access-Control-Allow-Origin', req.headers.origin);
+  res.setHeader('Access-Control-Allow-Credentials', 'true');
+  next();
+});
@@ -0,0 +1 @@
+CORS(app, supports_credentials=True)
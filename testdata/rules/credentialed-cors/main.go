@@ -0,0 +1,6 @@
+package main
+
+var c = cors.New(cors.Options{
+	AllowedOrigins:   []string{"https://app.example.com"},
+	AllowCredentials: true,
+})
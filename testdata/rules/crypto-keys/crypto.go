@@ -0,0 +1,7 @@
+package main
+
+func seal() {
+	block, _ := aes.NewCipher([]byte("0123456789abcdef"))
+	live, _ := aes.NewCipher(loadKey())
+	mac := hmac.New(sha256.New, []byte(os.Getenv("MAC_KEY")))
+}
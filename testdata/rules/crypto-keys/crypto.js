@@ -0,0 +1,4 @@
+const staticKey = 'k3y-0f-th1rty-tw0-bytes-long-abc';
+const staticIv = 'iv-sixteen-byte!';
+const cipher = crypto.createCipheriv('aes-256-cbc', staticKey, staticIv);
+const sig = crypto.createHmac('sha256', process.env.SECRET);
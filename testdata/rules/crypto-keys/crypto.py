@@ -0,0 +1,2 @@
+f = Fernet(b'ZmVybmV0LWtleS1mb3ItdGVzdHMtb25seS0xMjM0NTY=')
+g = Fernet(settings.FERNET_KEY)
@@ -0,0 +1 @@
+app.get('/api/invoices/:id', getInvoice);
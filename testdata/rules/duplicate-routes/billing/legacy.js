@@ -0,0 +1,2 @@
+app.get('/api/invoices/{id}', getInvoiceLegacy);
+app.post('/api/invoices/:id', updateInvoice);
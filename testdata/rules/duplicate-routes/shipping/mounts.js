@@ -0,0 +1,2 @@
+app.use('/api', auth);
+app.use('/api', audit);
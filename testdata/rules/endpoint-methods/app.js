@@ -0,0 +1,3 @@
+app.get('/api/users', list);
+app.post('/api/users', create);
+app.use('/static', serve);
@@ -0,0 +1,6 @@
+package main
+
+func main() {
+	http.HandleFunc("/health", health)
+	r.DELETE("/api/users/:id", remove)
+}
@@ -0,0 +1,3 @@
+urlpatterns = [
+    path('reports/', views.reports),
+]
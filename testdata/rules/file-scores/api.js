@@ -0,0 +1,2 @@
+app.post('/api/upload', upload.single('file'), handler);
+app.get('/api/items', handler);
@@ -0,0 +1 @@
+app.get('/healthz', handler);
@@ -0,0 +1,2 @@
+@GetMapping("/api/orders")
+fun orders() = listOf<String>()
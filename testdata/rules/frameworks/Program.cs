@@ -0,0 +1 @@
+app.MapPost("/api/carts", () => Results.Ok());
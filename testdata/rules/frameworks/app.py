@@ -0,0 +1,3 @@
+@app.route('/login')
+def login():
+    pass
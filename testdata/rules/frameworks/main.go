@@ -0,0 +1,6 @@
+package main
+
+func routes() {
+	r.GET("/api/items", list)
+	http.HandleFunc("/healthz", health)
+}
@@ -0,0 +1 @@
+router.get('/api/users', handler);
@@ -0,0 +1,6 @@
+const server = new ApolloServer({
+  typeDefs,
+  resolvers,
+  allowBatchedHttpRequests: false,
+  validationRules: [depthLimit(10), costAnalysis({ maximumCost: 1000 })],
+});
@@ -0,0 +1,12 @@
+package graph
+
+func (r *mutationResolver) CreateOrder(ctx context.Context, input NewOrder) (*Order, error) {
+	if auth.ForContext(ctx) == nil {
+		return nil, errUnauthorized
+	}
+	return r.orders.Create(input)
+}
+
+func (r *mutationResolver) CancelOrder(ctx context.Context, id string) (*Order, error) {
+	return r.orders.Cancel(id)
+}
@@ -0,0 +1,11 @@
+import strawberry
+
+@strawberry.type
+class Mutation:
+    @strawberry.mutation(permission_classes=[IsAuthenticated])
+    def rename(self, name: str) -> User:
+        return rename_user(name)
+
+    @strawberry.mutation
+    def reset(self) -> bool:
+        return reset_all()
@@ -0,0 +1 @@
+func (r *mutationResolver) SingleUpload(ctx context.Context, file graphql.Upload) (bool, error) {
@@ -0,0 +1,2 @@
+const { graphqlUploadExpress } = require('graphql-upload');
+app.use(graphqlUploadExpress({ maxFileSize: 1000000 }));
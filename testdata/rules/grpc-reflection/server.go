@@ -0,0 +1,6 @@
+package main
+
+func serve() {
+	s := grpc.NewServer()
+	reflection.Register(s)
+}
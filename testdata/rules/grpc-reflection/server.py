@@ -0,0 +1,3 @@
+server = grpc.server(futures.ThreadPoolExecutor())
+reflection.enable_server_reflection(SERVICE_NAMES, server)
+server.add_secure_port("[::]:50051", creds)
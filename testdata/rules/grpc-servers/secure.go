@@ -0,0 +1,7 @@
+package main
+
+func serve() {
+	creds, _ := credentials.NewServerTLSFromFile(cert, key)
+	s := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(authInterceptor))
+	pb.RegisterUsersServer(s, &users{})
+}
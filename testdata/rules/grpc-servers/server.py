@@ -0,0 +1,3 @@
+server = grpc.server(futures.ThreadPoolExecutor(), interceptors=[AuthInterceptor()])
+server.add_insecure_port("[::]:50051")
+server.add_secure_port("[::]:50052", creds)
@@ -0,0 +1,2 @@
+const server = new grpc.Server();
+server.bindAsync("0.0.0.0:50051", grpc.ServerCredentials.createInsecure(), () => server.start());
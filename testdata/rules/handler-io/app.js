@@ -0,0 +1,8 @@
+app.get('/api/rates', async (req, res) => {
+  const rates = await fetch('https://rates.example.com/latest');
+  res.json(await rates.json());
+});
+app.get('/api/quotes', async (req, res) => {
+  const quotes = await fetch(url, { signal: AbortSignal.timeout(2000) });
+  res.json(await quotes.json());
+});
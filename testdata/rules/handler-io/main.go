@@ -0,0 +1,16 @@
+package main
+
+func routes() {
+	http.HandleFunc("/api/orders", listOrders)
+	http.HandleFunc("/api/users", listUsers)
+}
+
+func listOrders(w http.ResponseWriter, r *http.Request) {
+	rows, _ := db.Query("SELECT id FROM orders LIMIT 10")
+	defer rows.Close()
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {
+	rows, _ := db.QueryContext(r.Context(), "SELECT id FROM users LIMIT 10")
+	defer rows.Close()
+}
@@ -0,0 +1,3 @@
+app.use(timeout('5s'));
+server.setTimeout(5000);
+app.get('/api/news', async (req, res) => res.json(await axios.get(feed)));
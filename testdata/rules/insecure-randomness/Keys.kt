@@ -0,0 +1 @@
+val apiKey = java.lang.Long.toHexString(Random().nextLong())
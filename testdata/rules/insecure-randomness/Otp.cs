@@ -0,0 +1 @@
+var otp = new Random().Next(100000, 999999).ToString();
@@ -0,0 +1,5 @@
+package auth
+
+import "crypto/rand"
+
+func newToken() { _, _ = rand.Read(token) }
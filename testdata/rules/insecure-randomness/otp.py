@@ -0,0 +1,5 @@
+import random
+
+otp = random.randint(100000, 999999)
+sample = random.choice(colors)
+nonce = secrets.token_hex(16)
@@ -0,0 +1,9 @@
+package auth
+
+import "math/rand"
+
+func newSession() string {
+	sessionID := fmt.Sprintf("%x", rand.Int63())
+	jitter := rand.Intn(100)
+	return sessionID
+}
@@ -0,0 +1,10 @@
+app.set('jsonp callback name', 'cb');
+app.get('/user', (req, res) => res.jsonp(user));
+app.get('/feed', (req, res) => {
+  const fn = req.query.jsonp;
+  res.type('js').send(fn + '(' + JSON.stringify(feed) + ')');
+});
+app.get('/label', (req, res) => {
+  const name = req.query.name;
+  res.send(name + '(' + count + ')');
+});
@@ -0,0 +1 @@
+app.get('/x', (req, res) => res.jsonp(data));
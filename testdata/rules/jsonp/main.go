@@ -0,0 +1,6 @@
+package main
+
+func data(w http.ResponseWriter, r *http.Request) {
+	cb := r.URL.Query().Get("callback")
+	fmt.Fprintf(w, "%s(%s)", cb, body)
+}
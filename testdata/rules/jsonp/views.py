@@ -0,0 +1,4 @@
+@app.route('/data')
+def data():
+    callback = request.args.get('callback')
+    return f"{callback}({json.dumps(rows)})"
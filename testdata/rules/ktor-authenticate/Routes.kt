@@ -0,0 +1,12 @@
+fun Application.module() {
+    routing {
+        authenticate("jwt") {
+            get("/me") {
+                call.respondText("me")
+            }
+        }
+        get("/orders") {
+            call.respondText("orders")
+        }
+    }
+}
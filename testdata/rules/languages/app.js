@@ -0,0 +1 @@
+app.get('/js', h);
@@ -0,0 +1,2 @@
+@app.route('/py')
+def view(): pass
@@ -0,0 +1 @@
+router.get('/ts', h);
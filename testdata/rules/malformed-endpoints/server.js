@@ -0,0 +1,3 @@
+const env = app.get('env');
+router.get('%s', handler);
+app.get('/api/health', handler);
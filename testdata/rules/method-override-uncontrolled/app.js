@@ -0,0 +1,2 @@
+app.use(methodOverride('_method'));
+app.get('/api/items', handler);
@@ -0,0 +1,3 @@
+const methodOverride = require('method-override');
+app.use(methodOverride('X-HTTP-Method-Override'));
+app.use(csrf({ cookie: true }));
@@ -0,0 +1 @@
+router.delete('/api/users/:id', requireAuth, handler);
@@ -0,0 +1,5 @@
+from flask import render_template
+
+@app.route('/')
+def index():
+    return render_template('index.html')
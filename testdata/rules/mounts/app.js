@@ -0,0 +1,2 @@
+app.use('/admin', requireAuth);
+app.use('/status', statusMiddleware({ version: pkg.version }));
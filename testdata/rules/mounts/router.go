@@ -0,0 +1,5 @@
+package main
+
+func routes(r chi.Router) {
+	r.Mount("/webhooks", webhookRouter())
+}
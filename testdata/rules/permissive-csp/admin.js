@@ -0,0 +1 @@
+app.use(helmet({ contentSecurityPolicy: false }));
@@ -0,0 +1,10 @@
+app.use(helmet({
+  contentSecurityPolicy: {
+    directives: {
+      defaultSrc: ["'self'"],
+      scriptSrc: ["'self'",
+        "'unsafe-eval'"],
+    },
+  },
+}));
+res.setHeader("Content-Security-Policy", "default-src *; style-src 'self' 'unsafe-inline'");
@@ -0,0 +1,15 @@
+package main
+
+func routes() {
+	http.HandleFunc("/healthz", healthz)
+	http.HandleFunc("/api/version", apiVersion)
+}
+
+func healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func apiVersion(w http.ResponseWriter, r *http.Request) {
+	_, _ = w.Write([]byte(runtime.Version()))
+}
@@ -0,0 +1,8 @@
+package main
+
+func upload(w http.ResponseWriter, r *http.Request) {
+	file, header, _ := r.FormFile("file")
+	defer file.Close()
+	dst, _ := os.Create("/tmp/" + header.Filename)
+	io.Copy(dst, file)
+}
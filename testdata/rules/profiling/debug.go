@@ -0,0 +1,9 @@
+package main
+
+import _ "net/http/pprof"
+
+func routes(mux *http.ServeMux) {
+	mux.Handle("/internal/vars", expvar.Handler())
+	mux.Handle("/stats", promhttp.Handler())
+	pprof.Register(router)
+}
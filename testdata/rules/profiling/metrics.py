@@ -0,0 +1,5 @@
+from prometheus_client import make_wsgi_app
+
+@login_required
+def setup():
+    app.wsgi_app = make_wsgi_app()
@@ -0,0 +1,5 @@
+package main
+
+import "runtime/pprof"
+
+func cpu(f *os.File) { pprof.StartCPUProfile(f) }
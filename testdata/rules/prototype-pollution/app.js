@@ -0,0 +1,20 @@
+const _ = require('lodash');
+function deepAssign(target, source) {
+  for (const key in source) {
+    if (typeof source[key] === 'object') {
+      target[key] = deepAssign(target[key] || {}, source[key]);
+    } else {
+      target[key] = source[key];
+    }
+  }
+  return target;
+}
+app.post('/api/settings', (req, res) => {
+  const prefs = req.body.prefs;
+  const merged = _.merge({}, req.body);
+  deepAssign(settings, prefs);
+  Object.assign(config, req.body);
+  Object.assign({}, req.body);
+  _.merge(defaults, overrides);
+  res.json(merged);
+});
@@ -0,0 +1,9 @@
+function safeAssign(target, source) {
+  for (const key in source) {
+    if (key === '__proto__' || key === 'constructor') continue;
+    target[key] = safeAssign(target[key], source[key]);
+  }
+}
+app.post('/api/profile', (req, res) => {
+  safeAssign(profile, req.body);
+});
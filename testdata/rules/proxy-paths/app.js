@@ -0,0 +1,3 @@
+app.get('/api/orders', handler);
+app.get('/internal/jobs', handler);
+app.get('/other', handler);
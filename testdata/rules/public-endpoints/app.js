@@ -0,0 +1,4 @@
+app.get('/v1/health', handler);
+app.get('/v2/health', handler);
+app.get('/public/docs/intro', handler);
+app.get('/api/orders', handler);
@@ -0,0 +1,16 @@
+Rails.application.routes.draw do
+  root "pages#home"
+  get "/health", to: "health#show"
+  resource :session, only: [:create, :destroy]
+  resources :users do
+    resources :posts, only: %i[index show]
+    member do
+      post :promote
+    end
+  end
+  namespace :admin do
+    resources :reports, except: [:destroy]
+    match "debug", to: "debug#index", via: [:get, :post]
+  end
+  mount Sidekiq::Web => "/sidekiq"
+end
@@ -0,0 +1,6 @@
+describe UsersController do
+  it "shows" do
+    get :show, params: { id: 1 }
+    Rails.logger.info(params[:password])
+  end
+end
@@ -0,0 +1,8 @@
+app.post('/admin/query', async (req, res) => {
+  const rows = await pool.query(req.body.sql);
+  res.json(rows);
+});
+app.get('/users', async (req, res) => {
+  const rows = await pool.query("SELECT * FROM users WHERE id = " + req.query.id);
+  res.json(rows);
+});
@@ -0,0 +1,8 @@
+package main
+
+func routes() { http.HandleFunc("/exec", run) }
+
+func run(w http.ResponseWriter, r *http.Request) {
+	rows, _ := db.QueryContext(r.Context(), r.FormValue("q"))
+	_ = rows
+}
@@ -0,0 +1,6 @@
+from elasticsearch import Elasticsearch
+
+@app.route("/search", methods=["POST"])
+def search():
+    query = request.json["query"]
+    return es.search(index="docs", body=query)
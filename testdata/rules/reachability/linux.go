@@ -0,0 +1,7 @@
+//go:build linux && amd64
+
+package main
+
+func init() {
+	http.HandleFunc("/linux", h)
+}
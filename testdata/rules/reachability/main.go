@@ -0,0 +1,14 @@
+package main
+
+func main() {
+	http.HandleFunc("/main", h)
+	registerAPI()
+}
+
+func registerAPI() {
+	http.HandleFunc("/api", h)
+}
+
+func registerDebug() {
+	http.HandleFunc("/debug/vars", h)
+}
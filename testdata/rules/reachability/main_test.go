@@ -0,0 +1,3 @@
+package main
+
+func TestDebug(t *testing.T) { registerDebug() }
@@ -0,0 +1,7 @@
+def setup(app):
+    @app.route('/legacy')
+    def legacy():
+        pass
+
+@app.route('/top')
+def top(): pass
@@ -0,0 +1,7 @@
+const EMAIL = /^([a-zA-Z0-9_.-])+@(([a-zA-Z0-9-])+\.)+$/;
+app.post('/signup', (req, res) => {
+  if (!EMAIL.test(req.body.email)) return res.sendStatus(400);
+  if (/^(\w+\s?)*$/.test(req.query.name)) ok();
+});
+const SLUG = /^(a+)+$/;
+function internal(s) { return SLUG.test(s); }
@@ -0,0 +1,4 @@
+class SignupDto {
+  @Matches(/^(a|aa)*$/)
+  code: string;
+}
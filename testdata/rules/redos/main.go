@@ -0,0 +1,5 @@
+package main
+
+var re = regexp.MustCompile(`^(a+)+$`)
+
+func h(r *http.Request) { re.MatchString(r.FormValue("q")) }
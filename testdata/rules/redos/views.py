@@ -0,0 +1,3 @@
+def search():
+    if re.match(r'^(\d+)*$', request.args.get('q')):
+        pass
@@ -0,0 +1,16 @@
+package main
+
+func echo(w http.ResponseWriter, r *http.Request) {
+	msg := r.URL.Query().Get("msg")
+	w.Write([]byte(msg))
+}
+
+func page(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<p>%s</p>", r.FormValue("name"))
+}
+
+func plain(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(r.URL.Path))
+}
@@ -0,0 +1,7 @@
+app.get('/echo', (req, res) => {
+  res.send(req.query.msg);
+});
+app.get('/echo.txt', (req, res) => {
+  res.type('text/plain');
+  res.send(req.query.msg);
+});
@@ -0,0 +1,9 @@
+package main
+
+func echo(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "you said %s", r.URL.Query().Get("say"))
+}
+
+func ping(c *gin.Context) {
+	c.String(200, c.Query("msg"))
+}
@@ -0,0 +1,9 @@
+app.get('/search', (req, res) => {
+  res.send('Results for ' + req.query.q);
+});
+app.get('/api/echo', (req, res) => {
+  res.json({ echo: req.query['msg'] });
+});
+app.get('/safe', (req, res) => {
+  res.send(escapeHtml(req.query.q));
+});
@@ -0,0 +1,9 @@
+@app.route('/hello')
+def hello():
+    return f"Hello {request.args.get('name')}"
+
+def page_number():
+    return request.args.get('page')
+
+def search(request):
+    return HttpResponse(request.GET['q'], content_type='text/plain')
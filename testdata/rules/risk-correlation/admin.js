@@ -0,0 +1,3 @@
+const upload = multer({ dest: 'uploads/' });
+app.post('/admin/import', upload.single('file'), importHandler);
+app.get('/api/catalog', listCatalog);
@@ -0,0 +1,3 @@
+app.use('/admin', requireAuth);
+app.get('/admin/users', (req, res) => res.json(users));
+app.post('/transfer', (req, res) => res.sendStatus(202));
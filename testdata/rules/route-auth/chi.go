@@ -0,0 +1,9 @@
+package main
+
+func routes(r chi.Router) {
+	r.Route("/refunds", func(r chi.Router) {
+		r.Use(AuthMiddleware)
+		r.Post("/approve", approve)
+	})
+	r.Get("/coupons", coupons)
+}
@@ -0,0 +1,10 @@
+package main
+
+func routes(r *gin.Engine) {
+	apiRouter := r.Group("/api")
+	apiRouter.Use(AuthMiddleware())
+	auditRouter := apiRouter.Group("/audit")
+	auditRouter.GET("/events", events)
+	publicRouter := r.Group("/public")
+	publicRouter.GET("/banners", banners)
+}
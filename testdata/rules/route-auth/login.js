@@ -0,0 +1,5 @@
+app.use(passport.initialize());
+app.use(passport.session());
+passport.use(new LocalStrategy(verifyUser));
+app.post('/authenticate', (req, res) => res.json(issueToken(req.body)));
+app.get('/statements', (req, res) => res.json(statements));
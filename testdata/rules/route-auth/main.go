@@ -0,0 +1,7 @@
+package main
+
+func routes(r *gin.Engine) {
+	admin := r.Group("/admin", AuthMiddleware())
+	admin.GET("/stats", stats)
+	r.POST("/payouts", payout)
+}
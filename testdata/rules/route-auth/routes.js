@@ -0,0 +1,5 @@
+router.get('/profile', requireAuth, (req, res) => res.json(req.user));
+router.post('/settings',
+  requireAuth,
+  (req, res) => res.sendStatus(204));
+router.get('/catalog', (req, res) => res.json(items));
@@ -0,0 +1,2 @@
+app.use(passport.authenticate('jwt', { session: false }));
+app.get('/invoices', (req, res) => res.json(invoices));
@@ -0,0 +1,16 @@
+@app.route('/account')
+@login_required
+def account():
+    return render_template('account.html')
+
+@app.route('/orders')
+def orders():
+    return jsonify(all_orders())
+@login_required
+@app.route('/billing')
+def billing():
+    return jsonify(invoices())
+
+@app.route('/reports')
+def reports():
+    return jsonify(all_reports())
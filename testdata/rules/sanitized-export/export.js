@@ -0,0 +1,5 @@
+const { Parser } = require('json2csv');
+const escapeFormula = (v) => (/^[=+\-@]/.test(v) ? "'" + v : v);
+app.post('/export', (req, res) => {
+  res.send(new Parser().parse(req.body.rows.map(escapeFormula)));
+});
@@ -0,0 +1 @@
+app.MapGet("/api/status", () => "ok").AllowAnonymous();
@@ -0,0 +1 @@
+fun filterChain(http: HttpSecurity) = http.csrf().disable().build()
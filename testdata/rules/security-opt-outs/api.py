@@ -0,0 +1,4 @@
+@app.route("/api/hooks", methods=["POST"])
+@limiter.exempt
+def hooks():
+    return "ok"
@@ -0,0 +1,5 @@
+from django.views.decorators.csrf import csrf_exempt
+
+@csrf_exempt
+def payment_callback(request):
+    return HttpResponse("ok")
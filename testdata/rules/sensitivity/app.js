@@ -0,0 +1,4 @@
+app.post('/api/creditCards/:id', handler);
+app.get('/api/users/:id/ssn', handler);
+app.get('/api/rewards', getLoyaltyPoints);
+app.get('/healthz', handler);
@@ -0,0 +1,3 @@
+@app.route('/api/records')
+def list_patient_records():
+    return jsonify([])
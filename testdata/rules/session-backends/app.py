@@ -0,0 +1,6 @@
+from flask import Flask, session
+
+@app.post('/login')
+def login():
+    session['user_id'] = user.id
+    session['api_token'] = user.token
@@ -0,0 +1 @@
+SESSION_ENGINE = 'django.contrib.sessions.backends.cache'
@@ -0,0 +1,8 @@
+from flask import Flask, session
+from flask_session import Session
+
+app.config['SESSION_TYPE'] = 'redis'
+Session(app)
+
+def login():
+    session['api_token'] = user.token
@@ -0,0 +1,15 @@
+app.post('/login', async (req, res) => {
+  const user = await authenticate(req.body);
+  req.session.userId = user.id;
+  res.redirect('/');
+});
+app.post('/signin', async (req, res) => {
+  const user = await authenticate(req.body);
+  req.session.regenerate(() => {
+    req.session.userId = user.id;
+    res.redirect('/');
+  });
+});
+app.post('/cart', (req, res) => {
+  req.session.user = 'guest';
+});
@@ -0,0 +1,10 @@
+def login_view(request):
+    user = User.objects.get(email=request.POST['email'])
+    if user.check_password(request.POST['password']):
+        request.session['user_id'] = user.id
+        return redirect('/')
+
+def signin(request):
+    user = authenticate(request, username=request.POST['u'], password=request.POST['p'])
+    login(request, user)
+    request.session['user_id'] = user.id
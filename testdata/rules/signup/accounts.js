@@ -0,0 +1,3 @@
+app.post('/api/signup', signup);
+app.get('/register', showForm);
+app.post('/login', login);
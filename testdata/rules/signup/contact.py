@@ -0,0 +1,5 @@
+from flask_limiter import Limiter
+from flask_wtf import RecaptchaField
+
+@app.post('/contact')
+def contact(): pass
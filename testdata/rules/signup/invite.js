@@ -0,0 +1,2 @@
+const rateLimit = require('express-rate-limit');
+app.post('/teams/:id/invite', rateLimit({ max: 5 }), invite);
@@ -0,0 +1,5 @@
+package main
+
+func routes(r *gin.Engine) {
+	r.POST("/register", verifyTurnstile, register)
+}
@@ -0,0 +1,5 @@
+app.post('/api/login', (req, res) => {
+  console.log(req.body.username);
+  logger.info(redact(req.headers));
+  console.log('Password changed for user', req.body.username);
+});
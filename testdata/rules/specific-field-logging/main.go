@@ -0,0 +1,5 @@
+package main
+
+func refresh() {
+	log.Printf("token refreshed")
+}
@@ -0,0 +1,2 @@
+logger.info("Password reset email sent")
+logger.info(f"Sending token reset to {user.email}")
@@ -0,0 +1,5 @@
+@Controller
+public class AdminController {
+    @DeleteMapping("/admin/cache")
+    public void flush() {}
+}
@@ -0,0 +1,21 @@
+@RestController
+public class ReportController {
+    @GetMapping(
+        value = "/reports/all",
+        params = {
+            "a",
+            "b",
+            "c",
+            "d",
+            "e",
+            "f",
+            "g"
+        }
+    )
+    public List<Report> all() { return reports.findAll(); }
+
+    @GetMapping("/reports/latest")
+    public Report latest() { return reports.latest(); }
+
+    @PostMapping(
+        value = "/reports"
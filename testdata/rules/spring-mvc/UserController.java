@@ -0,0 +1,27 @@
+package com.example.api;
+
+@RestController
+@RequestMapping(
+    value = "/api/users",
+    produces = "application/json"
+)
+public class UserController {
+
+    @GetMapping
+    public List<User> list() { return users.findAll(); }
+
+    @GetMapping("/{id}")
+    public User get(@PathVariable long id) { return users.find(id); }
+
+    @PostMapping(
+        path = "/{id}/roles",
+        consumes = "application/json"
+    )
+    public void grant(@PathVariable long id, @RequestBody Role role) {}
+
+    @RequestMapping(value = "/export", method = RequestMethod.POST)
+    public void export() {}
+
+    @RequestMapping(value = "/search", method = {RequestMethod.GET, RequestMethod.POST})
+    public List<User> search() { return users.findAll(); }
+}
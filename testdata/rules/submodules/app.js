@@ -0,0 +1 @@
+app.get('/api/orders', handler);
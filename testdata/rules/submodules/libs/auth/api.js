@@ -0,0 +1 @@
+app.get('/auth/login', handler);
@@ -0,0 +1 @@
+talisman = Talisman(app)
@@ -0,0 +1,52 @@
+resource "aws_security_group" "web" {
+  name = "web"
+
+  ingress {
+    from_port   = 443
+    to_port     = 443
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0"] # public HTTPS
+  }
+
+  ingress {
+    from_port = 22
+    to_port   = 22
+    protocol  = "tcp"
+    cidr_blocks = [
+      "10.0.0.0/8",
+      "::/0",
+    ]
+  }
+
+  ingress {
+    from_port   = 5432
+    to_port     = 5432
+    protocol    = "tcp"
+    cidr_blocks = ["10.0.0.0/8"]
+  }
+
+  tags = {
+    Team = "api"
+  }
+}
+
+resource "aws_lb" "public" {
+  load_balancer_type = "application"
+}
+
+resource "aws_lb" "private" {
+  internal = true
+}
+
+resource "aws_db_instance" "main" {
+  port                = 5432
+  publicly_accessible = true
+}
+
+resource "google_compute_firewall" "ssh" {
+  source_ranges = ["0.0.0.0/0"]
+  allow {
+    protocol = "tcp"
+    ports    = ["22", "3389"]
+  }
+}
@@ -0,0 +1,11 @@
+package main
+
+func check(r *http.Request) bool {
+	if r.Header.Get("X-Token") == "" || len(providedToken) != len(expected) {
+		return false
+	}
+	if providedToken != expected {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(apiToken), []byte(expected)) == 1
+}
@@ -0,0 +1,5 @@
+function verify(req) {
+  if (typeof req.headers['x-api-key'] !== 'string') return false;
+  return req.headers['x-api-key'] === process.env.API_KEY;
+}
+const ok = crypto.timingSafeEqual(Buffer.from(signature), Buffer.from(expected));
@@ -0,0 +1,4 @@
+def verify(request):
+    if hmac.compare_digest(request.token, settings.TOKEN):
+        return True
+    return request.headers.get("X-Signature") == expected_signature
@@ -0,0 +1 @@
+assert response.token == token
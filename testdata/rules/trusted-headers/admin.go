@@ -0,0 +1,14 @@
+package api
+
+func admin(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Admin") == "true" {
+		deleteAll()
+	}
+	ip := r.Header.Get("X-Forwarded-For")
+	if ip == "" {
+		ip = r.RemoteAddr
+	}
+	if r.Header.Get("Authorization") == "" {
+		return
+	}
+}
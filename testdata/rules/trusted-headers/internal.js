@@ -0,0 +1,5 @@
+function allow(req, res, next) {
+  const ip = req.headers['x-real-ip'];
+  if (allowlist.includes(ip)) return next();
+  res.sendStatus(403);
+}
@@ -0,0 +1,3 @@
+def profile(request):
+    if request.META.get('HTTP_X_USER_ID') != str(profile.owner_id):
+        raise PermissionDenied
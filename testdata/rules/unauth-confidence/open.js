@@ -0,0 +1,2 @@
+app.get('/api/users', handler);
+app.get('/api/catalog', handler);
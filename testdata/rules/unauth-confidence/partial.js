@@ -0,0 +1,2 @@
+const token = req.headers['x-token'];
+app.get('/api/reports', handler);
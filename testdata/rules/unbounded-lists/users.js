@@ -0,0 +1,11 @@
+app.get('/api/users', async (req, res) => {
+  const users = await db.collection('users').find({}).toArray();
+  res.json(users);
+});
+app.get('/api/orders', async (req, res) => {
+  const orders = await Order.find().limit(req.query.limit || 50);
+  res.json(orders);
+});
+app.get('/api/users/:id', async (req, res) => {
+  res.json(await User.findById(req.params.id));
+});
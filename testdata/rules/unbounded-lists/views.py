@@ -0,0 +1,4 @@
+@app.route('/products')
+def products():
+    rows = db.execute("SELECT * FROM products").fetchall()
+    return jsonify(rows)
@@ -0,0 +1,2 @@
+app.get('/api/users/:id', getUser);
+app.post('/api/orders', createOrder);
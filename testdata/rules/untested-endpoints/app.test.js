@@ -0,0 +1,4 @@
+test('gets a user', async () => {
+  await request(app).get('/api/users/42?expand=true');
+});
+app.get('/api/fixtures', serveFixture);
@@ -0,0 +1,2 @@
+const upload = multer({ dest: 'uploads/' });
+import { uploadLimits } from './limits';
@@ -0,0 +1,14 @@
+package main
+
+func avatar(w http.ResponseWriter, r *http.Request) {
+	file, header, _ := r.FormFile("avatar")
+	save(file, header)
+}
+
+func document(w http.ResponseWriter, r *http.Request) {
+	file, header, _ := r.FormFile("doc")
+	if filepath.Ext(header.Filename) != ".pdf" {
+		return
+	}
+	save(file, header)
+}
@@ -0,0 +1,12 @@
+ALLOWED_EXTENSIONS = {'png', 'jpg'}
+
+@app.post('/photos')
+def photos():
+    f = request.files['photo']
+    if not allowed_file(f.filename):
+        abort(400)
+
+@app.post('/attachments')
+def attachments():
+    f = request.files['file']
+    f.save('/tmp/x')
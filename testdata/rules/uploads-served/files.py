@@ -0,0 +1,14 @@
+UPLOAD_FOLDER = "media/uploads"
+
+@app.route("/upload", methods=["POST"])
+def upload():
+    f = request.files["file"]
+    f.save(os.path.join(app.config["UPLOAD_FOLDER"], secure_filename(f.filename)))
+
+@app.route("/files/<name>")
+def download(name):
+    return send_from_directory(app.config["UPLOAD_FOLDER"], name)
+
+@app.route("/exports/<name>")
+def export(name):
+    return send_from_directory(app.config["UPLOAD_FOLDER"], name, as_attachment=True)
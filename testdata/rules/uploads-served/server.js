@@ -0,0 +1,5 @@
+const multer = require('multer');
+const upload = multer({ dest: 'uploads/' });
+app.post('/avatar', upload.single('avatar'), (req, res) => res.sendStatus(201));
+app.use('/uploads', express.static(path.join(__dirname, 'uploads')));
+app.use(express.static('assets'));
@@ -0,0 +1,14 @@
+const app = express();
+app.post('/webhooks/stripe', (req, res) => {
+  const event = stripe.webhooks.constructEvent(req.body, req.headers['stripe-signature'], secret);
+  res.sendStatus(200);
+});
+app.post('/webhooks/github', (req, res) => {
+  handlePush(req.body);
+  res.sendStatus(200);
+});
+app.post('/events', (req, res) => {
+  const sig = req.get('X-Slack-Signature');
+  queue.push(req.body);
+  res.sendStatus(200);
+});
@@ -0,0 +1,14 @@
+const io = require('socket.io')(server);
+io.on('connection', (socket) => {
+  socket.on('rename', async (data) => {
+    await Room.update({ name: data.name }, { where: { id: data.id } });
+  });
+  socket.on('typing', (data) => {
+    socket.broadcast.emit('typing', data);
+  });
+  socket.on('delete', async (data) => {
+    if (!socket.data.user) return;
+    await Room.destroy({ where: { id: data.id } });
+  });
+  socket.on('disconnect', () => { Session.delete(socket.id); });
+});
@@ -0,0 +1,7 @@
+@WebSocketGateway()
+export class Gateway {
+  @SubscribeMessage('transfer')
+  handle(@MessageBody() body: Dto) {
+    return this.accounts.transferFunds(body.from, body.to);
+  }
+}
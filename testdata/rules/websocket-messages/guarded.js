@@ -0,0 +1,5 @@
+const io = require('socket.io')(server);
+io.use(authenticate);
+io.on('connection', (socket) => {
+  socket.on('rename', async (data) => { await Room.update(data); });
+});
@@ -0,0 +1,2 @@
+const net = require('net');
+socket.on('data', (d) => { Log.create(d); });
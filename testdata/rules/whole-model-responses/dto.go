@@ -0,0 +1,10 @@
+package api
+
+type User struct {
+	PasswordHash string `json:"-"`
+}
+
+func h(c *gin.Context) {
+	r.GET("/profile", h)
+	c.JSON(http.StatusOK, user)
+}
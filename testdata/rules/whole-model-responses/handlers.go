@@ -0,0 +1,6 @@
+package api
+
+func getUser(c *gin.Context) {
+	r.GET("/users/:id", getUser)
+	c.JSON(http.StatusOK, currentUser)
+}
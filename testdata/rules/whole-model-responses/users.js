@@ -0,0 +1,10 @@
+app.get('/api/users/:id', async (req, res) => {
+  const user = await User.findById(req.params.id);
+  res.json(user);
+});
+app.get('/api/me', async (req, res) => {
+  res.json({ id: req.user.id, name: req.user.name });
+});
+app.get('/api/items', async (req, res) => {
+  res.json(items);
+});
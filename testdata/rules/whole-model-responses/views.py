@@ -0,0 +1,4 @@
+@app.route('/accounts/<int:id>')
+def account(id):
+    acct = Account.query.get(id)
+    return jsonify(acct.__dict__)
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Handlers doing I/O without a timeout ---
+
+// handlerIOCalls match database and outbound HTTP calls per language.
+// call labels the I/O reported in metadata.
+var handlerIOCalls = map[string][]struct {
+	call string
+	re   *regexp.Regexp
+}{
+	".go": {
+		{"db.Query", regexp.MustCompile(`\.Query(?:Row)?\s*\(`)},
+		{"db.Exec", regexp.MustCompile(`\.Exec\s*\(`)},
+		{"db.Prepare", regexp.MustCompile(`\.Prepare\s*\(`)},
+		{"http.Get", regexp.MustCompile(`\bhttp\.(?:Get|Post|PostForm|Head)\s*\(`)},
+		{"http.NewRequest", regexp.MustCompile(`\bhttp\.NewRequest\s*\(`)},
+		{"client.Do", regexp.MustCompile(`\b\w*[Cc]lient\.Do\s*\(`)},
+	},
+	".js": {
+		{"fetch", regexp.MustCompile(`\bfetch\s*\(`)},
+		{"axios", regexp.MustCompile(`\baxios(?:\.(?:get|post|put|patch|delete|request))?\s*\(`)},
+		{"got", regexp.MustCompile(`\bgot(?:\.(?:get|post|put|patch|delete))?\s*\(`)},
+		{"http.request", regexp.MustCompile(`\bhttps?\.(?:request|get)\s*\(`)},
+		{"db.query", regexp.MustCompile(`\b(?:db|pool|client|connection|conn|knex)\.(?:query|execute|raw)\s*\(`)},
+	},
+}
+
+var (
+	// reIOTimeout matches timeouts, deadlines, cancellation signals, and
+	// request context propagation in a handler.
+	reIOTimeout = regexp.MustCompile(`(?i)timeout|deadline|\.Context\(\)|WithContext\b|\w+Context\s*\(|AbortController|AbortSignal|\bsignal\s*:`)
+
+	// reServerTimeout matches server-wide request timeouts that bound every
+	// handler in a file.
+	reServerTimeout = regexp.MustCompile(`\bhttp\.TimeoutHandler\s*\(|\bmiddleware\.Timeout\s*\(|\b(?:ReadTimeout|WriteTimeout)\s*:|\bconnect-timeout\b|\b(?:server|app)\.(?:setTimeout|requestTimeout|timeout)\b|\brequestTimeout\s*:`)
+)
+
+// ioWithoutTimeout returns the first I/O call in the handler for the route
+// on lines[i], and its label, when the handler neither sets a timeout nor
+// propagates the request context.
+func ioWithoutTimeout(lines []string, defs map[string]int, i int, ext string) (call, label string) {
+	if jsExtensions[ext] {
+		ext = ".js"
+	}
+	calls := handlerIOCalls[ext]
+	if calls == nil {
+		return "", ""
+	}
+	body := handlerBody(lines, defs, i, ext)
+	if anyLineMatches(body, reIOTimeout) {
+		return "", ""
+	}
+	for _, line := range body {
+		for _, c := range calls {
+			if c.re.MatchString(line) {
+				return strings.TrimSpace(line), c.call
+			}
+		}
+	}
+	return "", ""
+}
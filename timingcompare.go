@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Timing-unsafe secret comparisons ---
+
+// compareOperand matches an identifier with field, index, and call
+// suffixes, or a literal. Call arguments may not nest.
+const compareOperand = `(?:[A-Za-z_$][\w$]*(?:\.[\w$]+|\[[^\]]*\]|\([^()]*\))*|"[^"]*"|'[^']*'|\d+)`
+
+var (
+	// reEqualityCompare matches a == b, a != b, and the JS strict forms.
+	// Groups: left operand, operator, right operand.
+	reEqualityCompare = regexp.MustCompile(`(` + compareOperand + `)\s*(===?|!==?)\s*(` + compareOperand + `)`)
+
+	// reEqualsCall matches equality methods and functions that compare in
+	// variable time. Groups: receiver or first argument, method, argument.
+	reEqualsCall = regexp.MustCompile(`(` + compareOperand + `)\.(equals|Equals|contentEquals)\s*\(\s*(` + compareOperand + `)\s*\)|\b(?:strings\.)?(EqualFold|String\.Equals|bytes\.Equal)\s*\(\s*(` + compareOperand + `)\s*,\s*(` + compareOperand + `)`)
+
+	// reSecretName matches names of values that must be compared in
+	// constant time.
+	reSecretName = regexp.MustCompile(`(?i)(?:token|secret|password|passwd|apikey|signature|hmac|digest|otp|csrf)s?$`)
+
+	// reConstantTimeCompare matches constant-time comparison functions.
+	reConstantTimeCompare = regexp.MustCompile(`\bsubtle\.ConstantTimeCompare\b|\bhmac\.(?:Equal|compare_digest)\b|\bcompare_digest\s*\(|\btimingSafeEqual\b|\bMessageDigest\.isEqual\b|\bFixedTimeEquals\b|\bsafe_str_cmp\b|\bconstant_time_compare\b|(?i:\bconstant_?time\w*\s*\()`)
+
+	// reNonSecretOperand matches operands compared for presence or type
+	// rather than value, including typeof results.
+	reNonSecretOperand = regexp.MustCompile(`^(?:nil|None|null|undefined|true|false|True|False|\d+|""|''|["'](?:string|undefined|object|number|function)["'])$`)
+
+	// reMeasureCall matches calls that measure or inspect a value instead
+	// of returning it.
+	reMeasureCall = regexp.MustCompile(`^(?:len|cap|type|typeof|isinstance|strlen|count|sizeof)\(`)
+
+	// reOperandWord matches the words of an operand, including quoted
+	// header names such as x-api-key.
+	reOperandWord = regexp.MustCompile(`[A-Za-z_][\w-]*`)
+)
+
+// operandName returns the last word of an operand without separators,
+// e.g. "xapikey" for req.headers['x-api-key'].
+func operandName(operand string) string {
+	words := reOperandWord.FindAllString(operand, -1)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.NewReplacer("_", "", "-", "").Replace(words[len(words)-1])
+}
+
+// isSecretOperand reports whether operand names a secret value.
+func isSecretOperand(operand string) bool {
+	if strings.HasPrefix(operand, `"`) || strings.HasPrefix(operand, "'") || reMeasureCall.MatchString(operand) {
+		return false
+	}
+	return reSecretName.MatchString(operandName(operand))
+}
+
+// timingUnsafeCompare returns the secret operand and the operator or
+// function when line compares a secret in variable time.
+func timingUnsafeCompare(line string) (variable, operator string) {
+	if reConstantTimeCompare.MatchString(line) {
+		return "", ""
+	}
+	check := func(a, b, op string) bool {
+		if reNonSecretOperand.MatchString(a) || reNonSecretOperand.MatchString(b) {
+			return false
+		}
+		for _, operand := range []string{a, b} {
+			if isSecretOperand(operand) {
+				variable, operator = operand, op
+				return true
+			}
+		}
+		return false
+	}
+	for _, m := range reEqualityCompare.FindAllStringSubmatch(line, -1) {
+		if check(m[1], m[3], m[2]) {
+			return variable, operator
+		}
+	}
+	for _, m := range reEqualsCall.FindAllStringSubmatch(line, -1) {
+		if m[2] != "" && check(m[1], m[3], m[2]) {
+			return variable, operator
+		}
+		if m[4] != "" && check(m[5], m[6], m[4]) {
+			return variable, operator
+		}
+	}
+	return "", ""
+}
+
+// reportTimingUnsafeCompare emits ATTACK-091 when lines[i] compares a
+// secret without a constant-time function.
+func reportTimingUnsafeCompare(resp *sdk.ResponseBuilder, filePath string, lines []string, i int) {
+	variable, operator := timingUnsafeCompare(lines[i])
+	if variable == "" {
+		return
+	}
+	newFinding(
+		resp,
+		"ATTACK-091",
+		fmt.Sprintf("Secret %s compared with %s instead of a constant-time function: %s", variable, operator, strings.TrimSpace(lines[i])),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("variable", variable).
+		WithMetadata("operator", operator).
+		Done()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Top-N shortlist ---
+
+// parseTopN reads the top_n input. It returns 0 when unset, which keeps
+// every finding.
+func parseTopN(v any) (int, error) {
+	if v == nil {
+		return 0, nil
+	}
+	n, ok := v.(float64)
+	if !ok {
+		return 0, fmt.Errorf("top_n must be a number, got %T", v)
+	}
+	if n < 1 || n != math.Trunc(n) {
+		return 0, fmt.Errorf("top_n must be a positive integer, got %v", n)
+	}
+	return int(n), nil
+}
+
+// escalations maps each endpoint and line that an ATTACK-060 finding
+// covers to the number of risk rules coinciding there.
+func escalations(findings []*pluginv1.Finding) map[string]int {
+	esc := make(map[string]int)
+	for _, f := range findings {
+		if f.GetRuleId() != "ATTACK-060" {
+			continue
+		}
+		n := len(strings.Split(f.GetMetadata()["rules"], ","))
+		for _, key := range escalationKeys(f, "") {
+			esc[key] = max(esc[key], n)
+		}
+	}
+	return esc
+}
+
+// escalationKeys returns the keys f is correlated under: its line, and its
+// endpoint, or the endpoint defined on its line when it has none.
+func escalationKeys(f *pluginv1.Finding, lineEndpoint string) []string {
+	keys := []string{"line:" + lineKey(f)}
+	endpoint := f.GetMetadata()["endpoint"]
+	if endpoint == "" {
+		endpoint = lineEndpoint
+	}
+	if endpoint != "" {
+		keys = append(keys, "endpoint:"+normalizeEndpoint(endpoint))
+	}
+	return keys
+}
+
+// applyTopN keeps the n highest-priority findings, ranked by severity,
+// then confidence, then how many risk rules ATTACK-060 found coinciding
+// on the finding's endpoint or line, with the deterministic finding order
+// breaking ties. Kept findings get a 1-based rank in metadata.
+// Diagnostics are kept and not ranked. It returns the number of ranked
+// findings before truncation.
+func applyTopN(out *pluginv1.InvokeToolResponse, n int) int {
+	var ranked, diagnostics []*pluginv1.Finding
+	lineEndpoints := make(map[string]string)
+	for _, f := range out.GetFindings() {
+		if f.GetRuleId() == "ATTACK-000" {
+			diagnostics = append(diagnostics, f)
+			continue
+		}
+		ranked = append(ranked, f)
+		if ep := f.GetMetadata()["endpoint"]; ep != "" {
+			lineEndpoints[lineKey(f)] = ep
+		}
+	}
+	esc := escalations(ranked)
+	escalation := make(map[*pluginv1.Finding]int, len(ranked))
+	for _, f := range ranked {
+		for _, key := range escalationKeys(f, lineEndpoints[lineKey(f)]) {
+			escalation[f] = max(escalation[f], esc[key])
+		}
+	}
+
+	sortFindings(&pluginv1.InvokeToolResponse{Findings: ranked})
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if sa, sb := severityRank(a.GetSeverity()), severityRank(b.GetSeverity()); sa != sb {
+			return sa > sb
+		}
+		if ca, cb := confidenceRank(a.GetConfidence()), confidenceRank(b.GetConfidence()); ca != cb {
+			return ca > cb
+		}
+		return escalation[a] > escalation[b]
+	})
+	total := len(ranked)
+	ranked = ranked[:min(n, total)]
+	for i, f := range ranked {
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string)
+		}
+		f.Metadata["rank"] = strconv.Itoa(i + 1)
+	}
+	out.Findings = append(ranked, diagnostics...)
+	return total
+}
+
+// topNNote returns the ATTACK-000 finding recording how many findings
+// top_n omitted, located at path.
+func topNNote(n, total int, path string) *pluginv1.Finding {
+	resp := sdk.NewResponse()
+	newFinding(
+		resp,
+		"ATTACK-000",
+		fmt.Sprintf("Top %d of %d finding(s) shown; %d omitted", min(n, total), total, max(0, total-n)),
+	).
+		At(path, 0, 0).
+		WithMetadata("top_n", strconv.Itoa(n)).
+		WithMetadata("total", strconv.Itoa(total)).
+		WithMetadata("omitted", strconv.Itoa(max(0, total-n))).
+		Done()
+	return resp.Build().GetFindings()[0]
+}
+
+// findingRank returns the top_n rank of f, or math.MaxInt when unranked.
+func findingRank(f *pluginv1.Finding) int {
+	if r, err := strconv.Atoi(f.GetMetadata()["rank"]); err == nil {
+		return r
+	}
+	return math.MaxInt
+}
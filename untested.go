@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Endpoints without test references ---
+
+var (
+	// reTestFileName matches test files: Go _test.go, JS/TS .test./.spec.,
+	// Python test_*.py and *_test.py, Kotlin *Test.kt/*Tests.kt, Java
+	// *Test.java/*Tests.java, C# *Test.cs/*Tests.cs, and Ruby
+	// *_test.rb/*_spec.rb.
+	reTestFileName = regexp.MustCompile(`_test\.(?:go|py|rb)$|_spec\.rb$|\.(?:test|spec)\.[jt]sx?$|^test_\w*\.py$|Tests?\.(?:kt|java|cs)$`)
+
+	// reTestLiteral matches single-line string literals.
+	reTestLiteral = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`")
+
+	// reLiteralPrefix strips an absolute URL's scheme and host from a
+	// literal.
+	reLiteralPrefix = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://[^/]+`)
+)
+
+// isTestFile reports whether the file at path is a test file.
+func isTestFile(path string) bool {
+	return reTestFileName.MatchString(filepath.Base(path))
+}
+
+// untestedEndpoint is an endpoint registered outside test files.
+type untestedEndpoint struct {
+	method, endpoint, file string
+	line                   int
+}
+
+// testReferenceTracker collects endpoints and the string literals of test
+// files across the scan, to report endpoints no test refers to.
+type testReferenceTracker struct {
+	endpoints []untestedEndpoint
+	literals  map[string]bool
+}
+
+func newTestReferenceTracker() *testReferenceTracker {
+	return &testReferenceTracker{literals: make(map[string]bool)}
+}
+
+// collect records the string literals of a test file that look like paths,
+// without query strings, fragments, or a scheme and host.
+func (t *testReferenceTracker) collect(lines []string) {
+	for _, line := range lines {
+		for _, lit := range reTestLiteral.FindAllString(line, -1) {
+			lit = reLiteralPrefix.ReplaceAllString(lit[1:len(lit)-1], "")
+			lit, _, _ = strings.Cut(lit, "?")
+			lit, _, _ = strings.Cut(lit, "#")
+			if strings.HasPrefix(lit, "/") {
+				t.literals[strings.TrimRight(lit, "/")] = true
+			}
+		}
+	}
+}
+
+// record adds an endpoint registered in a non-test file.
+func (t *testReferenceTracker) record(method, endpoint, file string, line int) {
+	if method == "MOUNT" {
+		return
+	}
+	t.endpoints = append(t.endpoints, untestedEndpoint{method: method, endpoint: endpoint, file: file, line: line})
+}
+
+// merge adds the endpoints and literals other recorded after t's.
+func (t *testReferenceTracker) merge(other *testReferenceTracker) {
+	t.endpoints = append(t.endpoints, other.endpoints...)
+	for lit := range other.literals {
+		t.literals[lit] = true
+	}
+}
+
+// endpointLiteralPattern returns a pattern matching test literals that
+// refer to endpoint: literal segments match case-sensitively and path
+// parameters match any one segment, including interpolations.
+func endpointLiteralPattern(endpoint string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, seg := range strings.Split(strings.Trim(endpoint, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		b.WriteString("/")
+		if loc := reRouteParam.FindStringIndex(seg); loc != nil && loc[0] == 0 && loc[1] == len(seg) {
+			b.WriteString(`[^/]+`)
+			continue
+		}
+		b.WriteString(regexp.QuoteMeta(seg))
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// report emits ATTACK-087 for every endpoint that no test file literal
+// refers to.
+func (t *testReferenceTracker) report(resp *sdk.ResponseBuilder) {
+	for _, e := range t.endpoints {
+		re := endpointLiteralPattern(e.endpoint)
+		tested := false
+		for lit := range t.literals {
+			if re.MatchString(lit) {
+				tested = true
+				break
+			}
+		}
+		if tested {
+			continue
+		}
+		f := newFinding(
+			resp,
+			"ATTACK-087",
+			fmt.Sprintf("Endpoint %s is not referenced by any test", e.endpoint),
+		).
+			At(e.file, e.line, e.line).
+			WithMetadata("endpoint", e.endpoint)
+		if e.method != "" {
+			f.WithMetadata("method", e.method)
+		}
+		f.Done()
+	}
+}
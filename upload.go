@@ -0,0 +1,105 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Upload destinations derived from client filenames ---
+
+var (
+	// The filename the client supplied with an upload.
+	reUploadFilename = regexp.MustCompile(`\.(?:filename|originalname|originalFilename|Filename)\b|\bfile\.name\b|\.files\.\w+\.name\b`)
+	// Calls that write or move a file, with the opening parenthesis last.
+	reUploadWrite = regexp.MustCompile(`\b(?:save|writeFile(?:Sync)?|createWriteStream|mv|rename(?:Sync)?|Create|OpenFile|WriteFile|open)\s*\(`)
+	// multer diskStorage filename callbacks: cb(null, file.originalname).
+	reMulterFilename = regexp.MustCompile(`\bcb\s*\(\s*null\s*,`)
+	// Destinations built by joining or concatenating a directory.
+	rePathBuild = regexp.MustCompile("(?:os\\.path\\.join|filepath\\.Join|path\\.(?:join|resolve)|Path)\\s*\\(|[\"'`]\\s*\\+|\\+\\s*[\"'`]|\\$\\{|\\bf[\"']")
+	// Sanitization or randomization of the stored name.
+	reUploadSafeName = regexp.MustCompile(`(?i)secure_filename|filepath\.Base|path\.basename|os\.path\.basename|sanitiz|uuid|randomUUID|randomBytes|token_hex|token_urlsafe|mkstemp|NamedTemporaryFile|CreateTemp|TempFile|nanoid`)
+	// Assignment right-hand sides, for destinations computed before the write.
+	reAssignedValue = regexp.MustCompile(`^\s*(?:(?:const|let|var)\s+)?\w+\s*:?=\s*(.+?);?\s*$`)
+)
+
+// uploadDestination returns the destination expression when line stores an
+// upload under the client-supplied filename without sanitizing or
+// randomizing it, or "". The destination is the write call's path argument
+// or, for paths computed ahead of the write, the assigned expression.
+func uploadDestination(line string) string {
+	if !reUploadFilename.MatchString(line) || reUploadSafeName.MatchString(line) {
+		return ""
+	}
+	if loc := reMulterFilename.FindStringIndex(line); loc != nil {
+		return firstCallArgument(line[loc[1]:])
+	}
+	if loc := reUploadWrite.FindStringIndex(line); loc != nil {
+		if dest := firstCallArgument(line[loc[1]:]); reUploadFilename.MatchString(dest) {
+			return dest
+		}
+	}
+	if !rePathBuild.MatchString(line) {
+		return ""
+	}
+	if m := reAssignedValue.FindStringSubmatch(line); len(m) > 1 {
+		return m[1]
+	}
+	return strings.TrimSpace(line)
+}
+
+// firstCallArgument returns the argument that starts s, up to the first
+// top-level comma or the parenthesis that closes the call.
+func firstCallArgument(s string) string {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return strings.TrimSpace(s[:i])
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(s[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// --- Upload type allowlists ---
+
+var (
+	// Calls and parameters that receive an uploaded file, as opposed to
+	// imports or paths that merely mention uploads.
+	reUploadReceive = regexp.MustCompile(`\bFormFile\s*\(|\bMultipartForm\b|\brequest\.files\b|\breq\.files?\b|\bmulter\s*\(|\.(?:single|array|fields)\s*\(\s*['"]|\bUploadFile\b|\bIFormFile\b|\bMultipartFile\b|\bformidable\s*\(|\bIncomingForm\s*\(|\b[Bb]usboy\s*\(|\bFileField\s*\(`)
+	// Extension or content-type checks against an allowed set.
+	reUploadAllowlist = regexp.MustCompile(`(?i)filepath\.Ext\s*\(|path\.extname\s*\(|splitext\s*\(|GetExtension\s*\(|\.endswith\s*\(|allowed_?(?:files?|ext\w*|types|mime\w*|content_?types?)\b|FileExtensionsValidator|fileFilter|DetectContentType\s*\(|guess_type\s*\(|magic\.from_|\bfiletype\.|mimetype\s*(?:===?|!==?|in\b|not\s+in\b)|content_?type\s*(?:===?|!==?|in\b|not\s+in\b)|\.includes\(\s*(?:\w+\.)*mimetype|allowlist|whitelist`)
+)
+
+// uploadScope returns the lines of the handler that receives an upload on
+// lines[i]: the route's handler when the line registers a route, else the
+// enclosing function. Uploads configured at the top level, such as a
+// multer instance, are scoped to the whole file.
+func uploadScope(lines []string, defs map[string]int, starts []int, i int, ext string, route bool) []string {
+	if route {
+		return append([]string{lines[i]}, handlerBody(lines, defs, i, ext)...)
+	}
+	if j := starts[i]; j >= 0 {
+		return handlerBody(lines, defs, j, ext)
+	}
+	return lines
+}
+
+// uploadAllowlist returns the extension or MIME allowlist check in scope,
+// or "".
+func uploadAllowlist(scope []string) string {
+	for _, line := range scope {
+		if m := reUploadAllowlist.FindString(line); m != "" {
+			return m
+		}
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Same-origin serving of uploaded files ---
+
+var (
+	// Constants and settings naming an upload directory:
+	// UPLOAD_FOLDER = "uploads", app.config["UPLOAD_FOLDER"] = ...,
+	// const uploadDir = path.join(__dirname, "uploads"), MEDIA_ROOT = BASE_DIR / "media".
+	reUploadDirSetting = regexp.MustCompile(`(?i)\b((?:upload|media)_?(?:folder|dir|directory|path|root)|uploads?_?dir)\b["']?\]?\s*:?=\s*(.+?);?\s*$`)
+
+	// Upload destinations configured on the upload middleware.
+	reUploadDestOption = regexp.MustCompile(`\b(?:dest|destination)\s*:\s*(["'][^"']+["'])`)
+	reMulterDestCB     = regexp.MustCompile(`\bcb\s*\(\s*null\s*,\s*(["'][^"']+["'])\s*\)`)
+
+	// Static serving registrations. Group 1 is the text following the call's
+	// opening parenthesis or the keyword argument's "=".
+	reStaticServes = []*regexp.Regexp{
+		regexp.MustCompile(`\bexpress\.static\s*\((.*)`),
+		regexp.MustCompile(`\bserveStatic\s*\((.*)`),
+		regexp.MustCompile(`\bsend_from_directory\s*\((.*)`),
+		regexp.MustCompile(`\b(?:res|response)\.sendFile\s*\((.*)`),
+		regexp.MustCompile(`\bhttp\.Dir\s*\((.*)`),
+		regexp.MustCompile(`\bdocument_root\s*=\s*(.*)`),
+		regexp.MustCompile(`\bstatic_folder\s*=\s*(.*)`),
+	}
+	// gin/echo r.Static("/uploads", "./uploads"): group 1 is the route and
+	// group 2 the directory argument onwards.
+	reGoStatic = regexp.MustCompile(`\.Static(?:FS)?\s*\(\s*["']([^"']*)["']\s*,\s*(.*)`)
+	// Express mount path in app.use("/uploads", express.static(...)).
+	reStaticMount = regexp.MustCompile(`\.use\s*\(\s*["']([^"']+)["']\s*,`)
+
+	// Serving that forces a download or sandboxes the content.
+	reSafeServing = regexp.MustCompile(`(?i)as_attachment\s*=\s*True|Content-Disposition|\battachment\b|sandbox`)
+
+	// Path roots that precede the meaningful directory in a join.
+	reJoinRoot = regexp.MustCompile(`^(?:__dirname|process\.cwd\(\)|BASE_DIR|settings\.BASE_DIR|os\.getcwd\(\)|os\.path\.dirname\(.*\)|Path\(__file__\).*)$`)
+	// Joins and path concatenation: path.join(a, b), os.path.join(a, b),
+	// filepath.Join(a, b), BASE_DIR / "media".
+	reJoinCall = regexp.MustCompile(`^(?:path\.(?:join|resolve)|os\.path\.join|filepath\.Join|Path)\s*\(`)
+	// Setting lookups: app.config["UPLOAD_FOLDER"], settings.MEDIA_ROOT,
+	// uploadDir. Only names that look like directory settings qualify.
+	reConfigSubscript = regexp.MustCompile(`\[\s*["'](\w+)["']\s*\]$`)
+	reSettingRef      = regexp.MustCompile(`(?i)(?:^|\.)(\w*(?:upload|media)\w*)$`)
+
+	// A single- or double-quoted string.
+	reStringLiteral = regexp.MustCompile(`["']([^"']*)["']`)
+)
+
+// dirRef is a directory used for storing or serving files.
+type dirRef struct {
+	// key is a normalized relative directory, or "$name" for a setting
+	// whose value could not be resolved.
+	key   string
+	file  string
+	line  int
+	route string
+}
+
+// uploadServingTracker collects upload directories and static-serving
+// registrations across the workspace to find uploads served back from the
+// application's own origin.
+type uploadServingTracker struct {
+	settings map[string]string
+	uploads  []dirRef
+	serves   []dirRef
+}
+
+func newUploadServingTracker() *uploadServingTracker {
+	return &uploadServingTracker{settings: make(map[string]string)}
+}
+
+// observe records upload directory settings anywhere, upload destinations
+// in files that handle uploads, and static-serving registrations.
+func (t *uploadServingTracker) observe(line, file string, lineNum int, uploadFile bool) {
+	if isCommentLine(line) {
+		return
+	}
+	if m := reUploadDirSetting.FindStringSubmatch(line); len(m) > 2 {
+		if dir := dirKey(m[2]); dir != "" && !strings.HasPrefix(dir, "$") {
+			t.settings[strings.ToLower(m[1])] = dir
+		}
+		t.uploads = append(t.uploads, dirRef{key: settingKey(m[1]), file: file, line: lineNum})
+	}
+	if uploadFile {
+		for _, re := range []*regexp.Regexp{reUploadDestOption, reMulterDestCB} {
+			if m := re.FindStringSubmatch(line); len(m) > 1 {
+				t.uploads = append(t.uploads, dirRef{key: literalDir(m[1]), file: file, line: lineNum})
+			}
+		}
+		if dest := uploadDestination(line); dest != "" {
+			if key := dirKey(dest); key != "" {
+				t.uploads = append(t.uploads, dirRef{key: key, file: file, line: lineNum})
+			}
+		}
+	}
+
+	if reSafeServing.MatchString(line) {
+		return
+	}
+	route := ""
+	if m := reStaticMount.FindStringSubmatch(line); len(m) > 1 {
+		route = m[1]
+	}
+	arg := ""
+	if m := reGoStatic.FindStringSubmatch(line); len(m) > 2 {
+		route, arg = m[1], m[2]
+	}
+	for _, re := range reStaticServes {
+		if m := re.FindStringSubmatch(line); arg == "" && len(m) > 1 {
+			arg = m[1]
+		}
+	}
+	if arg == "" {
+		return
+	}
+	if key := dirKey(firstCallArgument(arg)); key != "" {
+		t.serves = append(t.serves, dirRef{key: key, file: file, line: lineNum, route: route})
+	}
+}
+
+// merge adds what other observed after t's files.
+func (t *uploadServingTracker) merge(other *uploadServingTracker) {
+	for k, v := range other.settings {
+		t.settings[k] = v
+	}
+	t.uploads = append(t.uploads, other.uploads...)
+	t.serves = append(t.serves, other.serves...)
+}
+
+// resolve replaces "$name" keys with the directory the setting was
+// assigned, when known.
+func (t *uploadServingTracker) resolve(key string) string {
+	if dir, ok := t.settings[strings.TrimPrefix(key, "$")]; ok && strings.HasPrefix(key, "$") {
+		return dir
+	}
+	return key
+}
+
+// report emits ATTACK-075 at each static-serving registration whose
+// directory holds uploaded files, listing the upload sites in metadata.
+func (t *uploadServingTracker) report(resp *sdk.ResponseBuilder) {
+	for _, s := range t.serves {
+		served := t.resolve(s.key)
+		var uploadDir string
+		var locations []string
+		for _, u := range t.uploads {
+			dir := t.resolve(u.key)
+			if !sameOrNestedDir(dir, served) {
+				continue
+			}
+			uploadDir = dir
+			loc := fmt.Sprintf("%s:%d", u.file, u.line)
+			if !containsString(locations, loc) {
+				locations = append(locations, loc)
+			}
+		}
+		if len(locations) == 0 {
+			continue
+		}
+		sort.Strings(locations)
+		f := newFinding(
+			resp,
+			"ATTACK-075",
+			fmt.Sprintf("Uploaded files in %s are served from the application origin; serve them with Content-Disposition: attachment and a sandboxing CSP, or from a separate domain", uploadDir),
+		).
+			At(s.file, s.line, s.line).
+			WithMetadata("upload_dir", uploadDir).
+			WithMetadata("served_dir", served).
+			WithMetadata("locations", strings.Join(locations, ","))
+		if s.route != "" {
+			f.WithMetadata("endpoint", s.route)
+		}
+		f.Done()
+	}
+}
+
+// sameOrNestedDir reports whether upload is served or lies below it.
+// Unresolved setting references only match the same setting.
+func sameOrNestedDir(upload, served string) bool {
+	if upload == "" || served == "" {
+		return false
+	}
+	if strings.HasPrefix(upload, "$") || strings.HasPrefix(served, "$") {
+		return upload == served
+	}
+	return upload == served || strings.HasPrefix(upload, served+"/")
+}
+
+// dirKey returns the directory an expression refers to: the meaningful
+// argument of a path join, a "$name" setting reference, or a literal path.
+func dirKey(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if loc := reJoinCall.FindStringIndex(expr); loc != nil {
+		for _, arg := range callArguments(expr[loc[1]:]) {
+			if !reJoinRoot.MatchString(arg) {
+				return dirKey(arg)
+			}
+		}
+		return ""
+	}
+	if m := reConfigSubscript.FindStringSubmatch(expr); len(m) > 1 {
+		return settingKey(m[1])
+	}
+	if dir := literalDir(expr); dir != "" {
+		return dir
+	}
+	if m := reSettingRef.FindStringSubmatch(expr); len(m) > 1 {
+		return settingKey(m[1])
+	}
+	return ""
+}
+
+// callArguments splits the arguments of a call whose opening parenthesis
+// precedes s.
+func callArguments(s string) []string {
+	var args []string
+	for {
+		arg := firstCallArgument(s)
+		if arg == "" {
+			return args
+		}
+		args = append(args, arg)
+		s = strings.TrimSpace(s[strings.Index(s, arg)+len(arg):])
+		if !strings.HasPrefix(s, ",") {
+			return args
+		}
+		s = s[1:]
+	}
+}
+
+// settingKey names an unresolved setting reference.
+func settingKey(name string) string {
+	return "$" + strings.ToLower(name)
+}
+
+// literalDir returns the normalized directory named by the first string
+// literal in expr, e.g. "./uploads/" + name and BASE_DIR / "media", or "".
+func literalDir(expr string) string {
+	m := reStringLiteral.FindStringSubmatch(expr)
+	if m == nil {
+		return ""
+	}
+	dir := path.Clean(m[1])
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
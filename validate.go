@@ -0,0 +1,64 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// --- Endpoint sanity checks ---
+
+// maxEndpointLength bounds extracted paths; longer strings are message
+// text or data that happened to sit in a route-shaped call.
+const maxEndpointLength = 512
+
+var (
+	// reFormatPlaceholder matches printf verbs and positional format
+	// arguments, which mark strings built for fmt/String.Format rather
+	// than route templates.
+	reFormatPlaceholder = regexp.MustCompile(`%[-+# 0]?[sdvqf]|\{\d*\}`)
+
+	// reRelativeSegment matches a bare path segment such as "login" or
+	// "details.json".
+	reRelativeSegment = regexp.MustCompile(`^[A-Za-z0-9][\w.~-]+$`)
+)
+
+// relativeRouteFrameworks register routes relative to an include, prefix,
+// or controller route, so a bare segment without "/" is a valid path.
+var relativeRouteFrameworks = map[string]bool{
+	"py-django":      true,
+	"cs-aspnet-mvc":  true,
+	"cs-minimal-api": true,
+	"kt-spring":      true,
+	"kt-micronaut":   true,
+	"java-spring":    true,
+}
+
+// validEndpoint reports whether an extracted endpoint looks like a URL
+// path: at most maxEndpointLength characters, no whitespace or control
+// characters, no format placeholders, and either containing "/" or being
+// a lone path parameter. Frameworks in relativeRouteFrameworks may also
+// use a bare segment. Regex routes are checked in simplified form.
+// Everything else, such as app.get('env') or a dict's .get('name'), is
+// a false match of a loose route pattern.
+func validEndpoint(endpoint, framework string) bool {
+	if isRegexRoute(endpoint) {
+		endpoint = simplifyRegexRoute(endpoint)
+	}
+	if endpoint == "" || len(endpoint) > maxEndpointLength {
+		return false
+	}
+	if strings.IndexFunc(endpoint, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return false
+	}
+	if reFormatPlaceholder.MatchString(endpoint) {
+		return false
+	}
+	if strings.Contains(endpoint, "/") {
+		return true
+	}
+	if loc := reRouteParam.FindStringIndex(endpoint); loc != nil && loc[0] == 0 && loc[1] == len(endpoint) {
+		return true
+	}
+	return relativeRouteFrameworks[framework] && reRelativeSegment.MatchString(endpoint)
+}
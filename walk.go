@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+)
+
+// --- Directory selection ---
+
+// alwaysSkippedDirs are never walked, whatever the inputs say: they take
+// precedence over include_hidden and include_dirs.
+var alwaysSkippedDirs = map[string]bool{
+	".git": true,
+}
+
+// hiddenSkippedDirs are hidden tool, cache, and editor directories skipped
+// by default, since they hold dependencies and generated files rather than
+// application code. Other hidden directories, such as .config, are walked.
+var hiddenSkippedDirs = map[string]bool{
+	".venv":         true,
+	".tox":          true,
+	".nox":          true,
+	".mypy_cache":   true,
+	".pytest_cache": true,
+	".ruff_cache":   true,
+	".cache":        true,
+	".idea":         true,
+	".vscode":       true,
+	".gradle":       true,
+	".terraform":    true,
+	".serverless":   true,
+	".next":         true,
+	".nuxt":         true,
+	".svelte-kit":   true,
+	".yarn":         true,
+	".pnpm-store":   true,
+}
+
+// dirFilter decides which directories a workspace walk descends into.
+// Directories in skippedDirs and hiddenSkippedDirs are skipped unless
+// carved out by includeHidden (for the hidden ones) or include;
+// alwaysSkippedDirs are skipped regardless.
+type dirFilter struct {
+	// includeHidden walks the directories in hiddenSkippedDirs.
+	includeHidden bool
+	// include names directories to walk even though they would be skipped.
+	include map[string]bool
+}
+
+// skip reports whether the directory called name should be skipped.
+func (f dirFilter) skip(name string) bool {
+	if alwaysSkippedDirs[name] {
+		return true
+	}
+	if f.include[name] {
+		return false
+	}
+	if hiddenSkippedDirs[name] {
+		return !f.includeHidden
+	}
+	return skippedDirs[name]
+}
+
+// parseDirFilter reads the include_hidden and include_dirs inputs.
+func parseDirFilter(input map[string]any) (dirFilter, error) {
+	var f dirFilter
+	f.includeHidden, _ = input["include_hidden"].(bool)
+	names, err := parseStringList(input["include_dirs"], "include_dirs")
+	if err != nil {
+		return f, err
+	}
+	f.include = make(map[string]bool, len(names))
+	for _, name := range names {
+		f.include[name] = true
+	}
+	return f, nil
+}
+
+// parseStringList reads an optional input that must be an array of strings.
+func parseStringList(v any, input string) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of strings, got %T", input, v)
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an array of strings, got element %T", input, item)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
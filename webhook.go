@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Webhook streaming ---
+
+const (
+	// webhookBatchSize is how many findings are buffered before a batch is
+	// posted during the walk.
+	webhookBatchSize = 100
+	// webhookInterval is the longest pending findings wait during the walk.
+	webhookInterval = 2 * time.Second
+	// webhookAttempts is how many times a batch is posted before giving up.
+	webhookAttempts = 4
+	// webhookQueue is how many batches may wait for the sender; while it is
+	// full, new findings stay pending instead of holding up the walk.
+	webhookQueue = 4
+	// webhookTimeout bounds each POST.
+	webhookTimeout = 10 * time.Second
+)
+
+// webhookBackoff is the wait before the first retry; it doubles after each
+// failed attempt. Tests shorten it.
+var webhookBackoff = 500 * time.Millisecond
+
+// webhookRetryBudget caps the time a scan spends waiting between retries,
+// across all batches; once spent, failed batches are dropped without
+// further attempts. Tests shorten it.
+var webhookRetryBudget = 30 * time.Second
+
+// webhookFinding is one finding in a webhook batch.
+type webhookFinding struct {
+	RuleID     string            `json:"rule_id"`
+	Severity   string            `json:"severity"`
+	Confidence string            `json:"confidence"`
+	Message    string            `json:"message"`
+	File       string            `json:"file"`
+	StartLine  int32             `json:"start_line"`
+	EndLine    int32             `json:"end_line"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// webhookBatch is the JSON body of one webhook POST. Final is set on the
+// last batch of a scan, which is sent even when it has no findings.
+type webhookBatch struct {
+	Findings []webhookFinding `json:"findings"`
+	Final    bool             `json:"final"`
+}
+
+// webhookStreamer posts findings to webhook_url in batches while the
+// workspace is walked, so consumers see results before the scan ends.
+// Streamed findings go through filter, the per-finding post-processing of
+// the response; the findings left after post-processing are sent in the
+// final batch. Batches are posted in order by a background sender, so
+// slow or failing deliveries never block the walk. Delivery failures are
+// collected, never fatal.
+type webhookStreamer struct {
+	url     string
+	client  *http.Client
+	filter  func(out *pluginv1.InvokeToolResponse)
+	sent    map[string]bool
+	pending []*pluginv1.Finding
+	last    time.Time
+
+	queue     chan webhookBatch
+	done      chan struct{}
+	closeOnce sync.Once
+	// retried and errs belong to the sender until done is closed.
+	retried time.Duration
+	errs    []error
+}
+
+// newWebhookStreamer validates rawURL, which must be an absolute http or
+// https URL, and starts the sender, which posts with ctx.
+func newWebhookStreamer(ctx context.Context, rawURL string, filter func(out *pluginv1.InvokeToolResponse)) (*webhookStreamer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook_url must be an absolute http or https URL")
+	}
+	w := &webhookStreamer{
+		url:    rawURL,
+		client: &http.Client{Timeout: webhookTimeout},
+		filter: filter,
+		sent:   make(map[string]bool),
+		last:   time.Now(),
+		queue:  make(chan webhookBatch, webhookQueue),
+		done:   make(chan struct{}),
+	}
+	go w.send(ctx)
+	return w, nil
+}
+
+// origin returns the scheme and host of the webhook URL. Diagnostics use
+// it instead of the URL, whose path or query may hold a token.
+func (w *webhookStreamer) origin() string {
+	u, _ := url.Parse(w.url)
+	return u.Scheme + "://" + u.Host
+}
+
+// collect queues findings, the raw findings of one merged file, and hands
+// a batch to the sender when enough are pending or the interval has
+// elapsed.
+func (w *webhookStreamer) collect(findings []*pluginv1.Finding) {
+	if len(findings) > 0 {
+		batch := &pluginv1.InvokeToolResponse{}
+		for _, f := range findings {
+			batch.Findings = append(batch.Findings, cloneFinding(f))
+		}
+		w.filter(batch)
+		for _, f := range batch.GetFindings() {
+			w.sent[webhookKey(f)] = true
+		}
+		w.pending = append(w.pending, batch.GetFindings()...)
+	}
+	if len(w.pending) >= webhookBatchSize || (len(w.pending) > 0 && time.Since(w.last) >= webhookInterval) {
+		w.flush(false)
+	}
+}
+
+// finish queues the pending findings and those in out that were not
+// streamed as the final batch, waits for the sender to deliver every
+// queued batch, and returns the delivery errors.
+func (w *webhookStreamer) finish(out *pluginv1.InvokeToolResponse) []error {
+	for _, f := range out.GetFindings() {
+		if !w.sent[webhookKey(f)] {
+			w.pending = append(w.pending, f)
+		}
+	}
+	w.flush(true)
+	w.close()
+	<-w.done
+	return w.errs
+}
+
+// close stops the sender once the queued batches are delivered. It is
+// safe to call more than once; after it, findings can no longer be
+// collected.
+func (w *webhookStreamer) close() {
+	w.closeOnce.Do(func() { close(w.queue) })
+}
+
+// flush hands the pending findings to the sender in batches of
+// webhookBatchSize. Until the final flush, a full queue leaves the rest
+// pending for a later call rather than waiting.
+func (w *webhookStreamer) flush(final bool) {
+	for {
+		n := min(len(w.pending), webhookBatchSize)
+		batch := webhookBatch{Findings: make([]webhookFinding, 0, n), Final: final && n == len(w.pending)}
+		for _, f := range w.pending[:n] {
+			batch.Findings = append(batch.Findings, webhookFinding{
+				RuleID:     f.GetRuleId(),
+				Severity:   severityNames[f.GetSeverity()],
+				Confidence: confidenceNames[f.GetConfidence()],
+				Message:    f.GetMessage(),
+				File:       f.GetLocation().GetFilePath(),
+				StartLine:  f.GetLocation().GetStartLine(),
+				EndLine:    f.GetLocation().GetEndLine(),
+				Metadata:   f.GetMetadata(),
+			})
+		}
+		if final {
+			w.queue <- batch
+		} else {
+			select {
+			case w.queue <- batch:
+			default:
+				return
+			}
+		}
+		w.pending = w.pending[n:]
+		if len(w.pending) == 0 {
+			break
+		}
+	}
+	w.pending = nil
+	w.last = time.Now()
+}
+
+// send posts the queued batches in order until the queue is closed.
+func (w *webhookStreamer) send(ctx context.Context) {
+	defer close(w.done)
+	for batch := range w.queue {
+		if err := w.post(ctx, batch); err != nil {
+			w.errs = append(w.errs, err)
+		}
+	}
+}
+
+// post sends one batch, retrying failed attempts with exponential backoff
+// while the retry budget lasts. The returned error never includes the URL.
+func (w *webhookStreamer) post(ctx context.Context, batch webhookBatch) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	backoff := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		err = w.attempt(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if attempt == webhookAttempts || ctx.Err() != nil || w.retried+backoff > webhookRetryBudget {
+			return fmt.Errorf("batch of %d finding(s) not delivered after %d attempt(s): %v", len(batch.Findings), attempt, err)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		w.retried += backoff
+		backoff *= 2
+	}
+}
+
+// attempt posts body once and fails on transport errors and non-2xx
+// statuses.
+func (w *webhookStreamer) attempt(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			return uerr.Err
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookDiagnostics returns the ATTACK-000 webhook diagnostic for errs,
+// post-processed like the response it is appended to. It is reported at
+// the URL's origin and is not itself delivered to the webhook.
+func webhookDiagnostics(w *webhookStreamer, errs []error, fingerprints *fingerprinter, idPrefix string) []*pluginv1.Finding {
+	c := &errorCollector{}
+	for _, err := range errs {
+		c.add(errKindWebhook, w.origin(), err)
+	}
+	resp := sdk.NewResponse()
+	c.report(resp)
+	out := resp.Build()
+	fingerprints.tag(out)
+	tagCategories(out)
+	if idPrefix != "" {
+		applyIDPrefix(out, idPrefix)
+	}
+	return out.GetFindings()
+}
+
+// webhookKey identifies a finding across streaming and the final
+// response: post-processing after the walk adds metadata but keeps the
+// rule, location, and fingerprint.
+func webhookKey(f *pluginv1.Finding) string {
+	loc := f.GetLocation()
+	return f.GetRuleId() + "\x00" + loc.GetFilePath() + "\x00" + strconv.Itoa(int(loc.GetStartLine())) + "\x00" + f.GetMetadata()["fingerprint"]
+}
+
+// cloneFinding copies f so post-processing a streamed batch leaves the
+// findings in the response builder untouched.
+func cloneFinding(f *pluginv1.Finding) *pluginv1.Finding {
+	c := &pluginv1.Finding{
+		RuleId:     f.GetRuleId(),
+		Severity:   f.GetSeverity(),
+		Confidence: f.GetConfidence(),
+		Message:    f.GetMessage(),
+		Metadata:   make(map[string]string, len(f.GetMetadata())),
+	}
+	if loc := f.GetLocation(); loc != nil {
+		c.Location = &pluginv1.Location{FilePath: loc.GetFilePath(), StartLine: loc.GetStartLine(), EndLine: loc.GetEndLine()}
+	}
+	for k, v := range f.GetMetadata() {
+		c.Metadata[k] = v
+	}
+	return c
+}
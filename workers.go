@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	pluginv1 "github.com/nox-hq/nox/gen/nox/plugin/v1"
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Concurrent file scanning ---
+
+// scanWorkers is the number of files scanned in parallel.
+var scanWorkers = runtime.NumCPU()
+
+// fileScan is the output of scanning one file: its findings and the
+// cross-file state it recorded, held until it is merged in walk order.
+type fileScan struct {
+	resp  *sdk.ResponseBuilder
+	shard *scanOptions
+	err   error
+	done  chan struct{}
+}
+
+// scanFiles runs scan for each of files on scanWorkers goroutines. Each
+// file is scanned into its own response and tracker shard, and merged
+// into opts in walk order, after which merged is called with its
+// response; the result is the same as scanning the files one by one.
+// Once ctx is done no further files start, and the first file that
+// returns an error is the last one merged; its error is returned.
+func scanFiles(
+	ctx context.Context,
+	files []string,
+	opts *scanOptions,
+	scan func(ctx context.Context, resp *sdk.ResponseBuilder, path string, shard *scanOptions) error,
+	merged func(resp *sdk.ResponseBuilder),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*fileScan, len(files))
+	for i := range results {
+		results[i] = &fileScan{done: make(chan struct{})}
+	}
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range files {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range max(scanWorkers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := results[i]
+				r.resp, r.shard = sdk.NewResponse(), opts.shard()
+				if r.err = ctx.Err(); r.err == nil {
+					r.err = scan(ctx, r.resp, files[i], r.shard)
+				}
+				close(r.done)
+			}
+		}()
+	}
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	for _, r := range results {
+		select {
+		case <-r.done:
+		case <-stopped:
+			select {
+			case <-r.done:
+			default:
+				// Never started: the scan was cancelled.
+				return ctx.Err()
+			}
+		}
+		opts.merge(r.shard)
+		merged(r.resp)
+		if r.err != nil {
+			return r.err
+		}
+	}
+	return nil
+}
+
+// shard returns a copy of o whose cross-file trackers are empty, for
+// scanning one file independently of the others. Settings are shared.
+func (o *scanOptions) shard() *scanOptions {
+	s := *o
+	s.errs = &errorCollector{}
+	s.routes = newRouteRegistry(o.routes.root, o.routes.serviceDepth)
+	s.apiURLs = newAPIURLInventory()
+	s.csp = &cspTracker{}
+	s.served = newUploadServingTracker()
+	s.methods = newMethodOverrideTracker(o.methods.root, o.methods.serviceDepth)
+	s.reach = newReachabilityTracker()
+	if o.baseline != nil {
+		s.baseline = &endpointBaseline{entries: o.baseline.entries, seen: make(map[string]bool)}
+	}
+	if o.inventory != nil {
+		s.inventory = &inventoryRecorder{root: o.inventory.root}
+	}
+	if o.coverage != nil {
+		s.coverage = &coverageTracker{}
+	}
+	if o.tests != nil {
+		s.tests = newTestReferenceTracker()
+	}
+	return &s
+}
+
+// merge adds what a shard's trackers recorded to o's, as if its file had
+// been scanned after every file already merged.
+func (o *scanOptions) merge(s *scanOptions) {
+	o.errs.merge(s.errs)
+	o.routes.merge(s.routes)
+	o.apiURLs.merge(s.apiURLs)
+	o.csp.merge(s.csp)
+	o.served.merge(s.served)
+	o.methods.merge(s.methods)
+	o.reach.merge(s.reach)
+	if o.baseline != nil {
+		o.baseline.merge(s.baseline)
+	}
+	if o.inventory != nil {
+		o.inventory.merge(s.inventory)
+	}
+	if o.coverage != nil {
+		o.coverage.merge(s.coverage)
+	}
+	if o.tests != nil {
+		o.tests.merge(s.tests)
+	}
+}
+
+// addFindings copies findings into resp.
+func addFindings(resp *sdk.ResponseBuilder, findings []*pluginv1.Finding) {
+	for _, f := range findings {
+		b := resp.Finding(f.GetRuleId(), f.GetSeverity(), f.GetConfidence(), f.GetMessage())
+		if loc := f.GetLocation(); loc != nil {
+			b.At(loc.GetFilePath(), int(loc.GetStartLine()), int(loc.GetEndLine()))
+		}
+		for k, v := range f.GetMetadata() {
+			b.WithMetadata(k, v)
+		}
+		b.Done()
+	}
+}
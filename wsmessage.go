@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nox-hq/nox/sdk"
+)
+
+// --- Per-message authorization in WebSocket handlers ---
+
+var (
+	// reWSEventHandler matches handlers for a named WebSocket event: NestJS
+	// @SubscribeMessage, socket.io/ws listeners, and Flask-SocketIO
+	// decorators. Group 1 or 2 is the event.
+	reWSEventHandler = regexp.MustCompile(`@SubscribeMessage\(\s*['"]([\w:./-]+)['"]|\b(?:socket|client|ws|conn|connection|socketio|sio)\.on\(\s*['"]([\w:./-]+)['"]`)
+
+	// reWSReadLoop matches reads of the next message in a receive loop:
+	// gorilla/websocket, nhooyr wsjson, and FastAPI/Starlette.
+	reWSReadLoop = regexp.MustCompile(`\b(?:\w+\.(?:ReadMessage|ReadJSON|NextReader)|wsjson\.Read|websocket\.receive(?:_text|_json|_bytes)?)\s*\(`)
+
+	// reWSStateChange matches writes in a message handler: persistence
+	// and mutation calls, and SQL statements that modify data. Group 1 or
+	// 2 is the operation.
+	reWSStateChange = regexp.MustCompile(`\.((?i:save|create|insert|update|delete|remove|destroy|upsert|transfer|withdraw|exec|commit)\w*)\s*\(|\b((?i:INSERT\s+INTO|UPDATE\s+\w+\s+SET|DELETE\s+FROM))\b`)
+
+	// reWSMessageAuth matches authentication, authorization, or origin
+	// checks in a message handler's scope.
+	reWSMessageAuth = regexp.MustCompile(`(?i)\borigin\b|authenticat|authoriz|requireAuth|verify\w*\(|\bjwt\b|\btoken\b|permission|current_user|\b(?:socket|client|request|req|ctx|session)\.(?:user|data\.user)\b|handshake\.auth|UseGuards`)
+
+	// reWSConnectionAuth matches connection-level checks that cover every
+	// message: gateway guards, socket.io middleware, ws verifyClient, and
+	// Flask-SocketIO's authenticated_only.
+	reWSConnectionAuth = regexp.MustCompile(`@UseGuards|\b(?:io|server|socketio|nsp|namespace)\.use\(|\bverifyClient\b|@authenticated_only|\bCheckOrigin\b`)
+
+	// reAllowAnyOrigin matches a CheckOrigin that accepts every origin.
+	reAllowAnyOrigin = regexp.MustCompile(`return\s+true`)
+)
+
+// wsLifecycleEvents are connection events rather than client messages.
+var wsLifecycleEvents = map[string]bool{
+	"connect":       true,
+	"connection":    true,
+	"disconnect":    true,
+	"disconnecting": true,
+	"close":         true,
+	"error":         true,
+	"open":          true,
+	"ping":          true,
+	"pong":          true,
+	"upgrade":       true,
+	"headers":       true,
+}
+
+// wsConnectionAuth reports whether lines check authentication or origin
+// for every connection. A CheckOrigin that returns true on its first lines
+// accepts any origin and does not count.
+func wsConnectionAuth(lines []string) bool {
+	for i, line := range lines {
+		if !reWSConnectionAuth.MatchString(line) {
+			continue
+		}
+		if strings.Contains(line, "CheckOrigin") && anyLineMatches(lines[i:min(i+3, len(lines))], reAllowAnyOrigin) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// wsMessageHandler returns the event lines[i] handles and the lines in
+// scope for its authorization check, or an empty event. Receive loops
+// handle every message; their scope is the enclosing function, where the
+// connection is usually authenticated before the loop.
+func wsMessageHandler(lines []string, defs map[string]int, starts []int, i int, ext string) (string, []string) {
+	if m := reWSEventHandler.FindStringSubmatch(lines[i]); m != nil {
+		event := m[1] + m[2]
+		if wsLifecycleEvents[event] {
+			return "", nil
+		}
+		return event, handlerBody(lines, defs, i, ext)
+	}
+	if reWSReadLoop.MatchString(lines[i]) {
+		start := starts[i]
+		if start < 0 {
+			return "", nil
+		}
+		return "message", handlerBody(lines, defs, start, ext)
+	}
+	return "", nil
+}
+
+// reportUnauthorizedWSMessage emits ATTACK-099 when lines[i] handles a
+// WebSocket message whose handler changes state with no authentication or
+// origin check in scope. connectionAuth is set when the file checks every
+// connection.
+func reportUnauthorizedWSMessage(resp *sdk.ResponseBuilder, filePath string, lines []string, defs map[string]int, starts []int, i int, ext string, connectionAuth bool) {
+	if connectionAuth {
+		return
+	}
+	event, scope := wsMessageHandler(lines, defs, starts, i, ext)
+	if event == "" || anyLineMatches(scope, reWSMessageAuth) {
+		return
+	}
+	operation := ""
+	for _, line := range scope {
+		if m := reWSStateChange.FindStringSubmatch(line); m != nil {
+			operation = m[1] + m[2]
+			break
+		}
+	}
+	if operation == "" {
+		return
+	}
+	newFinding(
+		resp,
+		"ATTACK-099",
+		fmt.Sprintf("WebSocket message %q changes state (%s) without an auth or origin check: %s", event, operation, strings.TrimSpace(lines[i])),
+	).
+		At(filePath, i+1, i+1).
+		WithMetadata("event", event).
+		WithMetadata("operation", operation).
+		Done()
+}